@@ -0,0 +1,37 @@
+// Package cases provides sorting instructions for [esort.Sorter] that compare
+// strings case-insensitively under the casing rules of a language.
+package cases
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/matttproud/esort"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// byLower sorts the data by a given string value lowercased with casers
+// created by newCaser.  A caser is not safe for concurrent use, so each
+// comparison borrows one from a pool.
+func byLower[T any](s *esort.Sorter[T], f func(T) string, newCaser func() cases.Caser, d esort.Dir) *esort.Sorter[T] {
+	pool := sync.Pool{New: func() any { c := newCaser(); return &c }}
+	fn := func(l, r T) int {
+		c := pool.Get().(*cases.Caser)
+		defer pool.Put(c)
+		return strings.Compare(c.String(f(l)), c.String(f(r)))
+	}
+	return s.ByFuncCmp(fn, d)
+}
+
+// ByTurkishFold sorts the data by a given string value case-insensitively
+// under the Turkish casing rules, which pair the dotless and dotted letters i
+// differently than other languages: "I" folds with "ı" (dotless i), and "İ"
+// (dotted capital I) folds with "i".  The folded strings are compared by code
+// point, so "i" sorts before "ı".
+//
+// The strings are lowercased upon each comparison, which allocates.  Prefer
+// lowercasing them once ahead of sorting for large data.
+func ByTurkishFold[T any](s *esort.Sorter[T], f func(T) string, d esort.Dir) *esort.Sorter[T] {
+	return byLower(s, f, func() cases.Caser { return cases.Lower(language.Turkish) }, d)
+}
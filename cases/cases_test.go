@@ -0,0 +1,46 @@
+package cases
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matttproud/esort"
+	"golang.org/x/exp/slices"
+)
+
+func TestByTurkishFold(t *testing.T) {
+	id := func(s string) string { return s }
+	for _, test := range []struct {
+		name    string
+		d       esort.Dir
+		in, out []string
+	}{
+		{
+			name: "i variants",
+			d:    esort.Asc,
+			in:   []string{"ı", "İ", "I", "i"},
+			out:  []string{"i", "İ", "I", "ı"},
+		},
+		{
+			name: "words asc",
+			d:    esort.Asc,
+			in:   []string{"Işık", "istanbul", "ılık", "İSTANBUL", "ISIK"},
+			out:  []string{"istanbul", "İSTANBUL", "ılık", "ISIK", "Işık"},
+		},
+		{
+			name: "words desc",
+			d:    esort.Desc,
+			in:   []string{"Işık", "istanbul", "ılık", "İSTANBUL", "ISIK"},
+			out:  []string{"Işık", "ISIK", "ılık", "istanbul", "İSTANBUL"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByTurkishFold(esort.New[string](), id, test.d).ByString(id, esort.Asc)
+			out := slices.Clone(test.in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%q) = %q, want %q\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+			}
+		})
+	}
+}
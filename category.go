@@ -0,0 +1,76 @@
+package esort
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneCategory is a coarse Unicode category of a rune.
+type RuneCategory int
+
+const (
+	// CategoryLetter is the category of runes for which unicode.IsLetter
+	// is true.
+	CategoryLetter = RuneCategory(iota)
+	// CategoryNumber is the category of runes for which unicode.IsNumber
+	// is true, such as decimal digits.
+	CategoryNumber
+	// CategoryPunct is the category of runes for which unicode.IsPunct is
+	// true.
+	CategoryPunct
+	// CategorySymbol is the category of runes for which unicode.IsSymbol
+	// is true, such as currency signs and emoji.
+	CategorySymbol
+	// CategoryOther is the category of all other runes, such as spaces,
+	// control characters, and combining marks.
+	CategoryOther
+)
+
+// runeCategory returns the RuneCategory of r.
+func runeCategory(r rune) RuneCategory {
+	switch {
+	case unicode.IsLetter(r):
+		return CategoryLetter
+	case unicode.IsNumber(r):
+		return CategoryNumber
+	case unicode.IsPunct(r):
+		return CategoryPunct
+	case unicode.IsSymbol(r):
+		return CategorySymbol
+	}
+	return CategoryOther
+}
+
+// ByFirstRuneCategory sorts the data by the category of the first rune of a
+// given string value, ordering the categories by their position in order.
+// Chain another instruction to order the strings within each category:
+//
+//	sorter := esort.New[Tag]().
+//		ByFirstRuneCategory(func(t Tag) string { return t.Name }, []esort.RuneCategory{esort.CategoryLetter, esort.CategoryNumber, esort.CategorySymbol}, esort.Asc).
+//		ByString(func(t Tag) string { return t.Name }, esort.Asc)
+//
+// Categories missing from order sort after those listed in ascending order and
+// tie with one another.  If a category appears in order more than once, its
+// first position is used.  Empty strings sort before all categories in ascending
+// order.  order is copied, so later modifications to it have no effect on the
+// Sorter.
+func (s *Sorter[T]) ByFirstRuneCategory(f func(T) string, order []RuneCategory, d Dir) *Sorter[T] {
+	var rank [CategoryOther + 1]int
+	for i := range rank {
+		rank[i] = len(order)
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		if c := order[i]; c >= CategoryLetter && c <= CategoryOther {
+			rank[c] = i
+		}
+	}
+	key := func(v T) int {
+		str := f(v)
+		if str == "" {
+			return -1
+		}
+		r, _ := utf8.DecodeRuneInString(str)
+		return rank[runeCategory(r)]
+	}
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d})
+}
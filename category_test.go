@@ -0,0 +1,47 @@
+package esort
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestByFirstRuneCategory(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"#tag", "42", "beta", "", "€5", " space", "Alpha", "7up", "(x)", "über"}
+	for _, test := range []struct {
+		name  string
+		order []RuneCategory
+		d     Dir
+		out   []string
+	}{
+		{
+			name:  "letters digits symbols asc",
+			order: []RuneCategory{CategoryLetter, CategoryNumber, CategorySymbol},
+			d:     Asc,
+			out:   []string{"", "Alpha", "beta", "über", "42", "7up", "€5", " space", "#tag", "(x)"},
+		},
+		{
+			name:  "punct first asc",
+			order: []RuneCategory{CategoryPunct, CategoryLetter, CategoryNumber, CategorySymbol, CategoryOther},
+			d:     Asc,
+			out:   []string{"", "#tag", "(x)", "Alpha", "beta", "über", "42", "7up", "€5", " space"},
+		},
+		{
+			name:  "letters digits symbols desc",
+			order: []RuneCategory{CategoryLetter, CategoryNumber, CategorySymbol},
+			d:     Desc,
+			out:   []string{" space", "#tag", "(x)", "€5", "42", "7up", "Alpha", "beta", "über", ""},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByFirstRuneCategory(id, test.order, test.d).ByString(id, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%q) = %q, want %q\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
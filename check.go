@@ -0,0 +1,47 @@
+package esort
+
+import "fmt"
+
+// CheckTotalOrder verifies that less is a strict weak ordering over samples,
+// as required of functions passed to [Sorter.ByFunc] and [sort.Slice].  It
+// checks that less is
+//
+//   - irreflexive: less(a, a) is false;
+//   - asymmetric: less(a, b) and less(b, a) are not both true;
+//   - transitive: less(a, b) and less(b, c) imply less(a, c); and
+//   - transitive in incomparability: if a and b are equivalent (neither is less
+//     than the other) and b and c are equivalent, a and c are equivalent.
+//
+// It returns an error describing the first counterexample it finds.
+//
+// CheckTotalOrder is a testing aid.  It evaluates every triple of samples, so
+// it takes O(n³) time for n samples; keep the samples small and include edge
+// cases like equal, zero, and extreme values.
+func CheckTotalOrder[T any](less func(l, r T) bool, samples []T) error {
+	for _, a := range samples {
+		if less(a, a) {
+			return fmt.Errorf("esort: comparator is not irreflexive: less(%v, %v) is true", a, a)
+		}
+	}
+	for _, a := range samples {
+		for _, b := range samples {
+			if less(a, b) && less(b, a) {
+				return fmt.Errorf("esort: comparator is not asymmetric: less(%v, %v) and less(%v, %v) are both true", a, b, b, a)
+			}
+		}
+	}
+	equiv := func(a, b T) bool { return !less(a, b) && !less(b, a) }
+	for _, a := range samples {
+		for _, b := range samples {
+			for _, c := range samples {
+				if less(a, b) && less(b, c) && !less(a, c) {
+					return fmt.Errorf("esort: comparator is not transitive: less(%v, %v) and less(%v, %v) but not less(%v, %v)", a, b, b, c, a, c)
+				}
+				if equiv(a, b) && equiv(b, c) && !equiv(a, c) {
+					return fmt.Errorf("esort: comparator equivalence is not transitive: %v ~ %v and %v ~ %v but not %v ~ %v", a, b, b, c, a, c)
+				}
+			}
+		}
+	}
+	return nil
+}
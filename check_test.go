@@ -0,0 +1,76 @@
+package esort
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckTotalOrder(t *testing.T) {
+	ints := []int{3, 1, 2, 2, 0, -1}
+	for _, test := range []struct {
+		name    string
+		less    func(l, r int) bool
+		samples []int
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			less:    func(l, r int) bool { return l < r },
+			samples: ints,
+		},
+		{
+			name:    "valid coarse",
+			less:    func(l, r int) bool { return l/2 < r/2 },
+			samples: ints,
+		},
+		{
+			name:    "reflexive",
+			less:    func(l, r int) bool { return l <= r },
+			samples: ints,
+			wantErr: true,
+		},
+		{
+			name:    "symmetric",
+			less:    func(l, r int) bool { return l != r },
+			samples: ints,
+			wantErr: true,
+		},
+		{
+			name: "intransitive",
+			// Rock-paper-scissors: 0 beats 1 beats 2 beats 0.
+			less:    func(l, r int) bool { return (l+1)%3 == r },
+			samples: []int{0, 1, 2},
+			wantErr: true,
+		},
+		{
+			name: "intransitive equivalence",
+			// Values within 1 of each other tie, but 0 and 2 do not.
+			less:    func(l, r int) bool { return l < r-1 },
+			samples: []int{0, 1, 2},
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			less:    func(l, r int) bool { return l <= r },
+			samples: nil,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckTotalOrder(test.less, test.samples)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("CheckTotalOrder(…, %v) = %v, want error %v", test.samples, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckTotalOrderFloatNaN(t *testing.T) {
+	less := func(l, r float64) bool { return l < r }
+	if err := CheckTotalOrder(less, []float64{0, math.NaN(), 1}); err == nil {
+		t.Errorf("CheckTotalOrder(<, [0 NaN 1]) = nil, want error")
+	}
+	s := New[float64]().ByFloat64TotalOrder(func(f float64) float64 { return f }, Asc)
+	if err := CheckTotalOrder(s.Less, []float64{0, math.NaN(), 1}); err != nil {
+		t.Errorf("CheckTotalOrder(ByFloat64TotalOrder, [0 NaN 1]) = %v, want nil", err)
+	}
+}
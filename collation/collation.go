@@ -0,0 +1,82 @@
+// Package collation provides sorting instructions for [esort.Sorter] that
+// compare strings by the collation rules of a language.
+package collation
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/matttproud/esort"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// germanPhonebook is the tag of the German phonebook collation.
+var germanPhonebook = language.MustParse("de-u-co-phonebk")
+
+// byCollator sorts the data by a given string value with collators created by
+// newCollator.  A collator is not safe for concurrent use, so each comparison
+// borrows one from a pool.
+func byCollator[T any](s *esort.Sorter[T], f func(T) string, newCollator func() *collate.Collator, d esort.Dir) *esort.Sorter[T] {
+	pool := sync.Pool{New: func() any { return newCollator() }}
+	fn := func(l, r T) int {
+		c := pool.Get().(*collate.Collator)
+		defer pool.Put(c)
+		return c.CompareString(f(l), f(r))
+	}
+	return s.ByFuncCmp(fn, d)
+}
+
+// ByGermanPhonebook sorts the data by a given string value according to the
+// German phonebook collation (DIN 5007-2), under which the umlauts collate as
+// their expansions: "ä" as "ae", "ö" as "oe", and "ü" as "ue".  "Göthe" thus
+// sorts with "Goethe" and before "Goldmann", whereas the standard German
+// collation sorts it with "Gothe" and after "Goldmann".
+//
+// Strings that collate equally, such as "Göthe" and "Goethe", are ordered by
+// their accents and case, so only identical strings tie.
+func ByGermanPhonebook[T any](s *esort.Sorter[T], f func(T) string, d esort.Dir) *esort.Sorter[T] {
+	return byCollator(s, f, func() *collate.Collator { return collate.New(germanPhonebook) }, d)
+}
+
+// Level is the strength of a collation: the level of difference between
+// strings at which comparison stops.
+type Level int
+
+const (
+	// Primary compares base letters only, so "resume", "résumé", and
+	// "Resume" tie.
+	Primary = Level(iota)
+	// Secondary also compares accents, so "resume" sorts before "résumé",
+	// but "resume" and "Resume" tie.
+	Secondary
+	// Tertiary also compares case and width, so only strings that differ in
+	// none of these tie.  It is the default strength of a collation.
+	Tertiary
+)
+
+// options returns the collate options that stop comparison at the level.
+func (l Level) options() []collate.Option {
+	switch l {
+	case Primary:
+		return []collate.Option{collate.Loose}
+	case Secondary:
+		return []collate.Option{collate.IgnoreCase}
+	case Tertiary:
+		return nil
+	}
+	panic(fmt.Errorf("collation: invalid level %d", int(l)))
+}
+
+// ByCollationStrength sorts the data by a given string value according to the
+// collation of the language tag at the given strength, such as to sort
+// accent-insensitively at [Primary] strength:
+//
+//	sorter := collation.ByCollationStrength(esort.New[Doc](), func(d Doc) string { return d.Title }, language.French, collation.Primary, esort.Asc)
+//
+// Strings that are equal up to the strength tie, so later instructions order
+// them.  ByCollationStrength panics if strength is not a valid Level.
+func ByCollationStrength[T any](s *esort.Sorter[T], f func(T) string, tag language.Tag, strength Level, d esort.Dir) *esort.Sorter[T] {
+	opts := strength.options()
+	return byCollator(s, f, func() *collate.Collator { return collate.New(tag, opts...) }, d)
+}
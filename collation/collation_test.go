@@ -0,0 +1,73 @@
+package collation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matttproud/esort"
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/language"
+)
+
+func TestByGermanPhonebook(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"Goldmann", "Göthe", "Goede", "Goethe", "Gothe", "Göbel"}
+	for _, test := range []struct {
+		name string
+		d    esort.Dir
+		out  []string
+	}{
+		{"asc", esort.Asc, []string{"Göbel", "Goede", "Goethe", "Göthe", "Goldmann", "Gothe"}},
+		{"desc", esort.Desc, []string{"Gothe", "Goldmann", "Göthe", "Goethe", "Goede", "Göbel"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByGermanPhonebook(esort.New[string](), id, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByCollationStrength(t *testing.T) {
+	type doc struct {
+		ID    int
+		Title string
+	}
+	title := func(d doc) string { return d.Title }
+	id := func(d doc) int { return d.ID }
+	in := []doc{{1, "résumé"}, {2, "Resume"}, {3, "rope"}, {4, "resume"}, {5, "rest"}}
+	for _, test := range []struct {
+		name     string
+		strength Level
+		out      []int
+	}{
+		{"primary", Primary, []int{5, 1, 2, 4, 3}},
+		{"secondary", Secondary, []int{5, 2, 4, 1, 3}},
+		{"tertiary", Tertiary, []int{5, 4, 2, 1, 3}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByCollationStrength(esort.New[doc](), title, language.English, test.strength, esort.Asc).ByInt(id, esort.Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []int
+			for _, d := range out {
+				got = append(got, d.ID)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByCollationStrengthInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ByCollationStrength(…, Level(7), …) did not panic")
+		}
+	}()
+	ByCollationStrength(esort.New[string](), func(s string) string { return s }, language.English, Level(7), esort.Asc)
+}
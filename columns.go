@@ -0,0 +1,67 @@
+package esort
+
+import "time"
+
+// ColKey is the typed value accessor of a [SortCol].  It is implemented only
+// by the values that [IntCol], [Float64Col], [StringCol], [BoolCol], and
+// [TimeCol] return.
+type ColKey[T any] interface {
+	apply(s *Sorter[T], d Dir) *Sorter[T]
+}
+
+type (
+	intCol[T any]     func(T) int
+	float64Col[T any] func(T) float64
+	stringCol[T any]  func(T) string
+	boolCol[T any]    func(T) bool
+	timeCol[T any]    func(T) time.Time
+)
+
+func (f intCol[T]) apply(s *Sorter[T], d Dir) *Sorter[T]     { return s.ByInt(f, d) }
+func (f float64Col[T]) apply(s *Sorter[T], d Dir) *Sorter[T] { return s.ByFloat64(f, d) }
+func (f stringCol[T]) apply(s *Sorter[T], d Dir) *Sorter[T]  { return s.ByString(f, d) }
+func (f boolCol[T]) apply(s *Sorter[T], d Dir) *Sorter[T]    { return s.ByBool(f, d) }
+func (f timeCol[T]) apply(s *Sorter[T], d Dir) *Sorter[T]    { return s.ByTime(f, d) }
+
+// IntCol returns a ColKey that sorts as [Sorter.ByInt].
+func IntCol[T any](f func(T) int) ColKey[T] { return intCol[T](f) }
+
+// Float64Col returns a ColKey that sorts as [Sorter.ByFloat64].
+func Float64Col[T any](f func(T) float64) ColKey[T] { return float64Col[T](f) }
+
+// StringCol returns a ColKey that sorts as [Sorter.ByString].
+func StringCol[T any](f func(T) string) ColKey[T] { return stringCol[T](f) }
+
+// BoolCol returns a ColKey that sorts as [Sorter.ByBool].
+func BoolCol[T any](f func(T) bool) ColKey[T] { return boolCol[T](f) }
+
+// TimeCol returns a ColKey that sorts as [Sorter.ByTime].
+func TimeCol[T any](f func(T) time.Time) ColKey[T] { return timeCol[T](f) }
+
+// SortCol is a column of a configuration-driven sort: a typed value accessor
+// and the direction to sort it in.
+type SortCol[T any] struct {
+	Key ColKey[T]
+	Dir Dir
+}
+
+// ApplyColumns adds an instruction for each of the columns in order, which
+// suits building a Sorter from configuration with type-checked accessors:
+//
+//	columns := map[string]esort.ColKey[Person]{
+//		"name": esort.StringCol(func(p Person) string { return p.GivenName }),
+//		"age":  esort.IntCol(func(p Person) int { return p.Age }),
+//	}
+//	var cols []esort.SortCol[Person]
+//	for _, c := range config.OrderBy {
+//		cols = append(cols, esort.SortCol[Person]{Key: columns[c.Name], Dir: c.Dir})
+//	}
+//	sorter := esort.New[Person]().ApplyColumns(cols)
+//
+// ApplyColumns panics if the Key of a column is nil.
+func (s *Sorter[T]) ApplyColumns(cols []SortCol[T]) *Sorter[T] {
+	for _, c := range cols {
+		s = c.Key.apply(s, c.Dir)
+	}
+	return s
+}
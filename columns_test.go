@@ -0,0 +1,62 @@
+package esort
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestApplyColumns(t *testing.T) {
+	type person struct {
+		Name   string
+		Active bool
+		Joined time.Time
+	}
+	t0 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	columns := map[string]ColKey[person]{
+		"name":   StringCol(func(p person) string { return p.Name }),
+		"active": BoolCol(func(p person) bool { return p.Active }),
+		"joined": TimeCol(func(p person) time.Time { return p.Joined }),
+	}
+	config := []struct {
+		Column string
+		Dir    Dir
+	}{
+		{"active", Desc},
+		{"joined", Asc},
+		{"name", Desc},
+	}
+	var cols []SortCol[person]
+	for _, c := range config {
+		cols = append(cols, SortCol[person]{Key: columns[c.Column], Dir: c.Dir})
+	}
+	s := New[person]().ApplyColumns(cols)
+	want := New[person]().
+		ByBool(func(p person) bool { return p.Active }, Desc).
+		ByTime(func(p person) time.Time { return p.Joined }, Asc).
+		ByString(func(p person) string { return p.Name }, Desc)
+	if !s.SameShape(want) {
+		t.Errorf("New().ApplyColumns(%v) does not have the same shape as the equivalent chain", config)
+	}
+	in := []person{
+		{"a", false, t0},
+		{"b", true, t0.Add(time.Hour)},
+		{"c", true, t0},
+		{"d", true, t0},
+		{"e", false, t0.Add(-time.Hour)},
+	}
+	out := slices.Clone(in)
+	slices.SortFunc(out, s.Less)
+	wantOut := []person{
+		{"d", true, t0},
+		{"c", true, t0},
+		{"b", true, t0.Add(time.Hour)},
+		{"e", false, t0.Add(-time.Hour)},
+		{"a", false, t0},
+	}
+	if diff := cmp.Diff(wantOut, out); diff != "" {
+		t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, wantOut, diff)
+	}
+}
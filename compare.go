@@ -0,0 +1,86 @@
+package esort
+
+// OrCmp sorts the data by the first non-zero result of the given three-way
+// comparison functions, like [cmp.Or] applied to their results.  Each function
+// returns a negative number when l sorts before r, a positive number when l
+// sorts after r, and zero otherwise, like [cmp.Compare].  Comparison functions
+// after the first non-zero result are not called.
+//
+// OrCmp eases migrating an existing cmp.Or-based comparator into a Sorter as a
+// single instruction:
+//
+//	sorter := esort.New[Person]().
+//		OrCmp(
+//			func(l, r Person) int { return cmp.Compare(l.GivenName, r.GivenName) },
+//			func(l, r Person) int { return cmp.Compare(l.ID, r.ID) },
+//		)
+//
+// Prefer the typed By methods for new code.
+//
+// [cmp.Or]: https://pkg.go.dev/cmp#Or
+// [cmp.Compare]: https://pkg.go.dev/cmp#Compare
+func (s *Sorter[T]) OrCmp(cmps ...func(l, r T) int) *Sorter[T] {
+	cmps = append([]func(l, r T) int(nil), cmps...)
+	fn := func(l, r T) int {
+		for _, c := range cmps {
+			if v := c(l, r); v != 0 {
+				return v
+			}
+		}
+		return 0
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: Asc, Kind: KindFunc})
+}
+
+// ByFuncCmp sorts the data according to an arbitrary three-way comparison
+// function.  f returns a negative number when l sorts before r, a positive
+// number when l sorts after r, and zero otherwise, like [cmp.Compare].
+//
+// Prefer ByFuncCmp over [Sorter.ByFunc] for costly comparisons.  A Sorter must
+// call a ByFunc function twice to tell whether an instruction is tied before
+// it consults the next one, whereas it calls a ByFuncCmp function once.
+//
+// [cmp.Compare]: https://pkg.go.dev/cmp#Compare
+func (s *Sorter[T]) ByFuncCmp(f func(l, r T) int, d Dir) *Sorter[T] {
+	return s.addInst(inst[T]{Cmp: f, Dir: d, Kind: KindFunc})
+}
+
+// ByLessStrict sorts the data according to a less function like
+// [Sorter.ByFunc] but derives a three-way result from it: l sorts before r if
+// less(l, r), after r if less(r, l), and ties otherwise.  A naive less that
+// compares a single criterion in one direction therefore behaves correctly at
+// any position in the program, including before other instructions:
+//
+//	sorter := esort.New[Person]().
+//		ByLessStrict(func(l, r Person) bool { return l.GivenName < r.GivenName }, esort.Asc).
+//		ByInt(func(p Person) int { return p.ID }, esort.Asc)
+//
+// less is called up to twice per comparison, so it costs up to twice as much
+// as an equivalent [Sorter.ByFuncCmp] function.
+func (s *Sorter[T]) ByLessStrict(less func(l, r T) bool, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		switch {
+		case less(l, r):
+			return -1
+		case less(r, l):
+			return 1
+		}
+		return 0
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindFunc})
+}
+
+// ByCmpChain adopts a hand-written three-way comparator, such as one chaining
+// cmp.Compare over several fields, as a single instruction.  It is equivalent
+// to [Sorter.ByFuncCmp] but names the intent of migrating such a comparator
+// incrementally:
+//
+//	sorter := esort.New[Person]().
+//		ByCmpChain(legacyComparePeople, esort.Asc).
+//		ByInt(func(p Person) int { return p.ID }, esort.Asc)
+//
+// [Desc] reverses the whole chain, just as negating the comparator's result
+// would, so every key it compares sorts in reverse.
+func (s *Sorter[T]) ByCmpChain(f func(l, r T) int, d Dir) *Sorter[T] {
+	return s.ByFuncCmp(f, d)
+}
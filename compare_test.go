@@ -0,0 +1,234 @@
+package esort
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// compare is a stand-in for cmp.Compare that does not collide with package
+// cmp from go-cmp.
+func compare[V constraints.Ordered](l, r V) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+func TestOrCmp(t *testing.T) {
+	in := []Data{
+		{Int: 1, String: "a", Uint: 2},
+		{Int: 0, String: "b", Uint: 1},
+		{Int: 1, String: "b", Uint: 0},
+		{Int: 0, String: "a", Uint: 3},
+		{Int: 1, String: "a", Uint: 1},
+		{Int: 0, String: "b", Uint: 2},
+	}
+	want := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Desc).
+		ByString(func(d Data) string { return d.String }, Asc).
+		ByUint(func(d Data) uint { return d.Uint }, Asc)
+	got := New[Data]().OrCmp(
+		func(l, r Data) int { return compare(r.Int, l.Int) },
+		func(l, r Data) int { return compare(l.String, r.String) },
+		func(l, r Data) int { return compare(l.Uint, r.Uint) },
+	)
+	wantOut := slices.Clone(in)
+	slices.SortFunc(wantOut, want.Less)
+	gotOut := slices.Clone(in)
+	slices.SortFunc(gotOut, got.Less)
+	if diff := cmp.Diff(wantOut, gotOut); diff != "" {
+		t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, gotOut, wantOut, diff)
+	}
+}
+
+func TestByFuncCmp(t *testing.T) {
+	in := []Data{
+		{Int: 1, String: "a"},
+		{Int: 0, String: "b"},
+		{Int: 1, String: "b"},
+		{Int: 0, String: "a"},
+	}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		out  []Data
+	}{
+		{
+			name: "asc desc",
+			s: New[Data]().
+				ByFuncCmp(func(l, r Data) int { return compare(l.Int, r.Int) }, Asc).
+				ByFuncCmp(func(l, r Data) int { return compare(l.String, r.String) }, Desc),
+			out: []Data{
+				{Int: 0, String: "b"},
+				{Int: 0, String: "a"},
+				{Int: 1, String: "b"},
+				{Int: 1, String: "a"},
+			},
+		},
+		{
+			name: "desc asc",
+			s: New[Data]().
+				ByFuncCmp(func(l, r Data) int { return compare(l.Int, r.Int) }, Desc).
+				ByFuncCmp(func(l, r Data) int { return compare(l.String, r.String) }, Asc),
+			out: []Data{
+				{Int: 1, String: "a"},
+				{Int: 1, String: "b"},
+				{Int: 0, String: "a"},
+				{Int: 0, String: "b"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func BenchmarkByFuncCmp(b *testing.B) {
+	var data []Data
+	for j := 0; j < 100; j++ {
+		data = append(data, benchData...)
+	}
+	for _, bench := range []struct {
+		name string
+		s    *Sorter[Data]
+	}{
+		{
+			name: "cmp",
+			s: New[Data]().
+				ByFuncCmp(func(l, r Data) int { return compare(l.Int, r.Int) }, Desc).
+				ByFuncCmp(func(l, r Data) int { return compare(l.Uint, r.Uint) }, Asc),
+		},
+		{
+			name: "less",
+			s: New[Data]().
+				ByFunc(func(l, r Data) bool { return l.Int < r.Int }, Desc).
+				ByFunc(func(l, r Data) bool { return l.Uint < r.Uint }, Asc),
+		},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			out := make([]Data, len(data))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(out, data)
+				slices.SortFunc(out, bench.s.Less)
+			}
+		})
+	}
+}
+
+func TestByLessStrict(t *testing.T) {
+	in := []Data{
+		{Int: 1, String: "a"},
+		{Int: 0, String: "b"},
+		{Int: 1, String: "b"},
+		{Int: 0, String: "a"},
+	}
+	naive := func(l, r Data) bool { return l.Int < r.Int }
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		out  []Data
+	}{
+		{
+			name: "asc desc",
+			s: New[Data]().
+				ByLessStrict(naive, Asc).
+				ByString(func(d Data) string { return d.String }, Desc),
+			out: []Data{
+				{Int: 0, String: "b"},
+				{Int: 0, String: "a"},
+				{Int: 1, String: "b"},
+				{Int: 1, String: "a"},
+			},
+		},
+		{
+			name: "desc asc",
+			s: New[Data]().
+				ByLessStrict(naive, Desc).
+				ByString(func(d Data) string { return d.String }, Asc),
+			out: []Data{
+				{Int: 1, String: "a"},
+				{Int: 1, String: "b"},
+				{Int: 0, String: "a"},
+				{Int: 0, String: "b"},
+			},
+		},
+		{
+			name: "terminal",
+			s: New[Data]().
+				ByString(func(d Data) string { return d.String }, Asc).
+				ByLessStrict(naive, Desc),
+			out: []Data{
+				{Int: 1, String: "a"},
+				{Int: 0, String: "a"},
+				{Int: 1, String: "b"},
+				{Int: 0, String: "b"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByCmpChain(t *testing.T) {
+	chain := func(l, r Data) int {
+		if c := compare(l.Int, r.Int); c != 0 {
+			return c
+		}
+		return compare(l.String, r.String)
+	}
+	in := []Data{
+		{Int: 1, String: "a"},
+		{Int: 0, String: "b"},
+		{Int: 1, String: "b"},
+		{Int: 0, String: "a"},
+		{Int: 2, String: "a"},
+	}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		want *Sorter[Data]
+	}{
+		{
+			name: "asc",
+			s:    New[Data]().ByCmpChain(chain, Asc),
+			want: New[Data]().
+				ByInt(func(d Data) int { return d.Int }, Asc).
+				ByString(func(d Data) string { return d.String }, Asc),
+		},
+		{
+			name: "desc",
+			s:    New[Data]().ByCmpChain(chain, Desc),
+			want: New[Data]().
+				ByInt(func(d Data) int { return d.Int }, Desc).
+				ByString(func(d Data) string { return d.String }, Desc),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			want := slices.Clone(in)
+			slices.SortFunc(want, test.want.Less)
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(want, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+			}
+		})
+	}
+}
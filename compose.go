@@ -0,0 +1,176 @@
+package esort
+
+import "context"
+
+// ByBranch sorts the data by one of two sorters depending on a predicate.
+// Pairs of elements that both satisfy pred are sorted by whenTrue, and pairs
+// that both do not are sorted by whenFalse.  A nil Sorter leaves its pairs
+// tied for the remaining instructions.
+//
+// In the mixed case, where only one element of a pair satisfies pred, the
+// element that does not satisfy pred sorts first, as with [Sorter.ByBool] in
+// ascending order.  Precede ByBranch with a ByBool instruction on pred to
+// control that placement explicitly.  Ordering the mixed case this way keeps
+// the instruction a consistent ordering for a given pred.
+func (s *Sorter[T]) ByBranch(pred func(T) bool, whenTrue, whenFalse *Sorter[T]) *Sorter[T] {
+	fn := func(l, r T) bool {
+		lp, rp := pred(l), pred(r)
+		switch {
+		case lp != rp:
+			return rp
+		case lp && whenTrue != nil:
+			return whenTrue.Less(l, r)
+		case !lp && whenFalse != nil:
+			return whenFalse.Less(l, r)
+		}
+		return false
+	}
+	return s.addInst(inst[T]{Func: fn, Dir: Asc})
+}
+
+// adaptInst converts an instruction on T into one on U by applying extract to
+// both operands first.
+func adaptInst[U, T any](i inst[T], extract func(U) T) inst[U] {
+	o := inst[U]{
+		Dir:    i.Dir,
+		Stable: i.Stable,
+		Label:  i.Label,
+		Kind:   i.Kind,
+	}
+	if f := i.CmpCtx; f != nil {
+		o.CmpCtx = func(ctx context.Context, l, r U, d Dir) int { return f(ctx, extract(l), extract(r), d) }
+	}
+	if draw := i.Draw; draw != nil {
+		o.Draw = func(data []U) func(l, r U, li, ri int) int {
+			ts := make([]T, len(data))
+			for j, v := range data {
+				ts[j] = extract(v)
+			}
+			f := draw(ts)
+			return func(l, r U, li, ri int) int { return f(ts[li], ts[ri], li, ri) }
+		}
+	}
+	switch {
+	case i.CmpDir != nil:
+		f := i.CmpDir
+		o.CmpDir = func(l, r U, d Dir) int { return f(extract(l), extract(r), d) }
+	case i.Cmp != nil:
+		f := i.Cmp
+		o.Cmp = func(l, r U) int { return f(extract(l), extract(r)) }
+	default:
+		f := i.Func
+		o.Func = func(l, r U) bool { return f(extract(l), extract(r)) }
+	}
+	return o
+}
+
+// Adapt returns a Sorter for U that performs every instruction of s on the T
+// extracted from each element by extract.  For instance, a Sorter for a type
+// can sort a wrapper around the type:
+//
+//	type Employee struct {
+//		Person
+//		Salary int
+//	}
+//
+//	byPerson := esort.Adapt(personSorter, func(e Employee) Person { return e.Person })
+//
+// The returned Sorter is independent of s, and further instructions can be
+// added to either without affecting the other.  extract is called twice for
+// every instruction evaluated in a comparison, so it should be cheap.
+func Adapt[U, T any](s *Sorter[T], extract func(U) T) *Sorter[U] {
+	prog := make([]inst[U], len(s.prog))
+	for i, o := range s.prog {
+		prog[i] = adaptInst(o, extract)
+	}
+	c := &Sorter[U]{prog: prog, recoverPanics: s.recoverPanics, parallelUnsafe: s.parallelUnsafe}
+	if fb := s.fallback; fb != nil {
+		c.fallback = func(l, r U) int { return fb(extract(l), extract(r)) }
+	}
+	return c
+}
+
+// derefInst converts an instruction on T into one on *T that dereferences
+// both operands first.  Nil pointers sort before non-nil ones regardless of
+// the instruction's direction.
+func derefInst[T any](o inst[T]) inst[*T] {
+	// nils orders l and r for direction d if either is nil.
+	nils := func(l, r *T, d Dir) (int, bool) {
+		less, ok := nullLess(l == nil, r == nil, NullsFirst, d)
+		switch {
+		case !ok:
+			return 0, false
+		case less:
+			return -1, true
+		case l == r:
+			return 0, true
+		}
+		return 1, true
+	}
+	fn := func(l, r *T, d Dir) int {
+		if v, ok := nils(l, r, d); ok {
+			return v
+		}
+		return o.compare(*l, *r, d)
+	}
+	d := inst[*T]{CmpDir: fn, Dir: o.Dir, Stable: o.Stable, Label: o.Label, Kind: o.Kind}
+	if draw := o.Draw; draw != nil {
+		// Nil pointers draw for the zero value of T but never reach f, since
+		// fn already orders them.
+		d.Draw = func(data []*T) func(l, r *T, li, ri int) int {
+			ts := make([]T, len(data))
+			for i, p := range data {
+				if p != nil {
+					ts[i] = *p
+				}
+			}
+			f := draw(ts)
+			return func(l, r *T, li, ri int) int {
+				if l == nil || r == nil {
+					return 0
+				}
+				return f(*l, *r, li, ri)
+			}
+		}
+	}
+	if f := o.CmpCtx; f != nil {
+		d.CmpCtx = func(ctx context.Context, l, r *T, d Dir) int {
+			if v, ok := nils(l, r, d); ok {
+				return v
+			}
+			return f(ctx, *l, *r, d)
+		}
+	}
+	return d
+}
+
+// Pointers returns a Sorter for pointers to T that performs every instruction
+// of s on the values pointed to, so that a Sorter for a type can sort a slice
+// of pointers to it.  Nil pointers sort before all non-nil pointers and tie
+// with one another.
+//
+//	people := []*Person{…}
+//	slices.SortFunc(people, esort.Pointers(sorter).Less)
+//
+// The returned Sorter is independent of s, and further instructions can be
+// added to either without affecting the other.  Pointers is a function rather
+// than a method, because Go does not permit a method of Sorter[T] to return a
+// Sorter[*T].
+func Pointers[T any](s *Sorter[T]) *Sorter[*T] {
+	prog := make([]inst[*T], len(s.prog))
+	for i, o := range s.prog {
+		prog[i] = derefInst(o)
+	}
+	c := &Sorter[*T]{prog: prog, recoverPanics: s.recoverPanics, parallelUnsafe: s.parallelUnsafe}
+	if fb := s.fallback; fb != nil {
+		// The instructions place nil pointers, so any pair reaching the
+		// fallback with a nil pointer holds two of them.
+		c.fallback = func(l, r *T) int {
+			if l == nil || r == nil {
+				return 0
+			}
+			return fb(*l, *r)
+		}
+	}
+	return c
+}
@@ -0,0 +1,160 @@
+package esort
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestByBranch(t *testing.T) {
+	type row struct {
+		Group    int
+		VIP      bool
+		Priority int
+		Date     string
+	}
+	vip := func(r row) bool { return r.VIP }
+	byPriority := New[row]().ByInt(func(r row) int { return r.Priority }, Desc)
+	byDate := New[row]().ByString(func(r row) string { return r.Date }, Asc)
+	group := func(r row) int { return r.Group }
+	in := []row{
+		{Group: 1, VIP: true, Priority: 1, Date: "2023-01-01"},
+		{Group: 1, VIP: false, Priority: 9, Date: "2023-01-03"},
+		{Group: 0, VIP: true, Priority: 2, Date: "2023-01-01"},
+		{Group: 0, VIP: false, Priority: 1, Date: "2023-01-02"},
+		{Group: 0, VIP: true, Priority: 3, Date: "2023-01-04"},
+		{Group: 0, VIP: false, Priority: 7, Date: "2023-01-01"},
+	}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[row]
+		out  []row
+	}{
+		{
+			name: "both branches",
+			s:    New[row]().ByInt(group, Asc).ByBranch(vip, byPriority, byDate),
+			out: []row{
+				{Group: 0, VIP: false, Priority: 7, Date: "2023-01-01"},
+				{Group: 0, VIP: false, Priority: 1, Date: "2023-01-02"},
+				{Group: 0, VIP: true, Priority: 3, Date: "2023-01-04"},
+				{Group: 0, VIP: true, Priority: 2, Date: "2023-01-01"},
+				{Group: 1, VIP: false, Priority: 9, Date: "2023-01-03"},
+				{Group: 1, VIP: true, Priority: 1, Date: "2023-01-01"},
+			},
+		},
+		{
+			name: "vip first",
+			s:    New[row]().ByInt(group, Asc).ByBool(vip, Desc).ByBranch(vip, byPriority, byDate),
+			out: []row{
+				{Group: 0, VIP: true, Priority: 3, Date: "2023-01-04"},
+				{Group: 0, VIP: true, Priority: 2, Date: "2023-01-01"},
+				{Group: 0, VIP: false, Priority: 7, Date: "2023-01-01"},
+				{Group: 0, VIP: false, Priority: 1, Date: "2023-01-02"},
+				{Group: 1, VIP: true, Priority: 1, Date: "2023-01-01"},
+				{Group: 1, VIP: false, Priority: 9, Date: "2023-01-03"},
+			},
+		},
+		{
+			name: "nil branch",
+			s: New[row]().ByBranch(vip, nil, byDate).
+				ByInt(func(r row) int { return r.Priority }, Asc),
+			out: []row{
+				{Group: 0, VIP: false, Priority: 7, Date: "2023-01-01"},
+				{Group: 0, VIP: false, Priority: 1, Date: "2023-01-02"},
+				{Group: 1, VIP: false, Priority: 9, Date: "2023-01-03"},
+				{Group: 1, VIP: true, Priority: 1, Date: "2023-01-01"},
+				{Group: 0, VIP: true, Priority: 2, Date: "2023-01-01"},
+				{Group: 0, VIP: true, Priority: 3, Date: "2023-01-04"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("normal", func(t *testing.T) {
+				out := slices.Clone(in)
+				slices.SortFunc(out, test.s.Less)
+				if diff := cmp.Diff(test.out, out); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+				}
+			})
+			t.Run("inverse", func(t *testing.T) {
+				out := slices.Clone(in)
+				reverse(out)
+				slices.SortFunc(out, test.s.Less)
+				if diff := cmp.Diff(test.out, out); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+				}
+			})
+		})
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	type wrapper struct {
+		V    int
+		Name string
+	}
+	ints := New[int]().ByInt(func(v int) int { return v % 3 }, Asc).ByInt(func(v int) int { return v }, Desc)
+	s := Adapt(ints, func(w wrapper) int { return w.V }).ByString(func(w wrapper) string { return w.Name }, Asc)
+	in := []wrapper{
+		{4, "a"},
+		{3, "b"},
+		{2, "c"},
+		{3, "a"},
+		{6, "d"},
+		{1, "e"},
+	}
+	want := []wrapper{
+		{6, "d"},
+		{3, "a"},
+		{3, "b"},
+		{4, "a"},
+		{1, "e"},
+		{2, "c"},
+	}
+	out := slices.Clone(in)
+	slices.SortFunc(out, s.Less)
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+	}
+	if got, want := len(ints.prog), 2; got != want {
+		t.Errorf("after Adapt, len(ints.prog) = %d, want %d", got, want)
+	}
+}
+
+func TestPointers(t *testing.T) {
+	s := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Desc).
+		ByString(func(d Data) string { return d.String }, Asc)
+	a := &Data{Int: 1, String: "a"}
+	b := &Data{Int: 1, String: "b"}
+	c := &Data{Int: 0, String: "a"}
+	in := []*Data{c, nil, b, a, nil}
+	want := []*Data{nil, nil, a, b, c}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[*Data]
+	}{
+		{"pointers", Pointers(s)},
+		{"pointers labeled", Pointers(s.Label("name"))},
+		{"pointers redirected", Pointers(s.SetDirections([]Dir{Asc, Desc})).SetDirections([]Dir{Desc, Asc})},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("normal", func(t *testing.T) {
+				out := slices.Clone(in)
+				slices.SortFunc(out, test.s.Less)
+				if diff := cmp.Diff(want, out); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+				}
+			})
+			t.Run("inverse", func(t *testing.T) {
+				out := slices.Clone(in)
+				reverse(out)
+				slices.SortFunc(out, test.s.Less)
+				if diff := cmp.Diff(want, out); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package esort
+
+import "context"
+
+// ByFuncCtx sorts the data according to a three-way comparison function like
+// [Sorter.ByFuncCmp] that also receives a context, such as one carrying the
+// requesting user's locale.  [Sorter.SortContext] passes its context to f, so
+// a Sorter built once at package level can compare per request:
+//
+//	var sorter = esort.New[Product]().ByFuncCtx(func(ctx context.Context, l, r Product) int {
+//		return collatorFrom(ctx).CompareString(l.Name, r.Name)
+//	}, esort.Asc)
+//
+//	sorter.SortContext(ctx, products)
+//
+// Everywhere else, such as in [Sorter.Less] and [Sorter.SortStable], f
+// receives [context.Background].  Sorters derived with [Adapt], [Pointers],
+// and [Sorter.WithCounters] keep passing the context along.
+func (s *Sorter[T]) ByFuncCtx(f func(ctx context.Context, l, r T) int, d Dir) *Sorter[T] {
+	bg := context.Background()
+	return s.addInst(inst[T]{
+		Cmp:    func(l, r T) int { return f(bg, l, r) },
+		CmpCtx: func(ctx context.Context, l, r T, _ Dir) int { return f(ctx, l, r) },
+		Dir:    d,
+		Kind:   KindFunc,
+	})
+}
+
+// withContext returns the Sorter with the instructions added by ByFuncCtx
+// bound to ctx.  It returns s itself if there are no such instructions.
+func (s *Sorter[T]) withContext(ctx context.Context) *Sorter[T] {
+	var c *Sorter[T]
+	for i, o := range s.prog {
+		if o.CmpCtx == nil {
+			continue
+		}
+		if c == nil {
+			cp := *s
+			cp.prog = append([]inst[T](nil), s.prog...)
+			c = &cp
+		}
+		f := o.CmpCtx
+		c.prog[i].Func, c.prog[i].Cmp = nil, nil
+		c.prog[i].CmpDir = func(l, r T, d Dir) int { return f(ctx, l, r, d) }
+	}
+	if c == nil {
+		return s
+	}
+	return c
+}
+
+// SortContext sorts the data in place according to the Sorter like
+// [Sorter.Sort], passing ctx to every comparison function added with
+// [Sorter.ByFuncCtx].  SortContext does not observe the cancellation of ctx;
+// the comparison functions may do so by panicking, which a Sorter created
+// with [Sorter.RecoverExtractorPanics] turns into a descriptive panic as Sort
+// does.
+func (s *Sorter[T]) SortContext(ctx context.Context, data []T) {
+	s.withContext(ctx).Sort(data)
+}
@@ -0,0 +1,92 @@
+package esort
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+type reverseKey struct{}
+
+func TestSortContext(t *testing.T) {
+	byString := func(ctx context.Context, l, r Data) int {
+		c := compare(l.String, r.String)
+		if rev, _ := ctx.Value(reverseKey{}).(bool); rev {
+			return -c
+		}
+		return c
+	}
+	s := New[Data]().
+		ByFuncCtx(byString, Asc).
+		ByInt(func(d Data) int { return d.Int }, Asc)
+	in := []Data{
+		{String: "b", Int: 1},
+		{String: "a", Int: 2},
+		{String: "c", Int: 0},
+		{String: "a", Int: 1},
+	}
+	forward := []Data{{String: "a", Int: 1}, {String: "a", Int: 2}, {String: "b", Int: 1}, {String: "c", Int: 0}}
+	backward := []Data{{String: "c", Int: 0}, {String: "b", Int: 1}, {String: "a", Int: 1}, {String: "a", Int: 2}}
+	rev := context.WithValue(context.Background(), reverseKey{}, true)
+	for _, test := range []struct {
+		name string
+		sort func([]Data)
+		out  []Data
+	}{
+		{"background", func(d []Data) { s.SortContext(context.Background(), d) }, forward},
+		{"reversed", func(d []Data) { s.SortContext(rev, d) }, backward},
+		{"less", func(d []Data) { slices.SortFunc(d, s.Less) }, forward},
+		{"pointers", func(d []Data) {
+			ptrs := make([]*Data, len(d))
+			for i := range d {
+				ptrs[i] = &d[i]
+			}
+			Pointers(s).SortContext(rev, ptrs)
+			out := make([]Data, len(d))
+			for i, p := range ptrs {
+				out[i] = *p
+			}
+			copy(d, out)
+		}, backward},
+		{"adapt", func(d []Data) {
+			type wrapper struct{ D Data }
+			w := make([]wrapper, len(d))
+			for i, v := range d {
+				w[i] = wrapper{v}
+			}
+			Adapt(s, func(w wrapper) Data { return w.D }).SortContext(rev, w)
+			for i, v := range w {
+				d[i] = v.D
+			}
+		}, backward},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			test.sort(out)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("sorting %v = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestSortContextCounters(t *testing.T) {
+	s, counters := New[int]().
+		ByFuncCtx(func(ctx context.Context, l, r int) int {
+			if rev, _ := ctx.Value(reverseKey{}).(bool); rev {
+				return compare(r, l)
+			}
+			return compare(l, r)
+		}, Asc).
+		WithCounters()
+	data := []int{1, 3, 2}
+	s.SortContext(context.WithValue(context.Background(), reverseKey{}, true), data)
+	if want := []int{3, 2, 1}; !slices.Equal(data, want) {
+		t.Errorf("s.SortContext(…) = %v, want %v", data, want)
+	}
+	if counters.Calls(0) == 0 {
+		t.Error("counters.Calls(0) = 0, want comparisons counted")
+	}
+}
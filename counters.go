@@ -0,0 +1,73 @@
+package esort
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Counters accumulates how often each instruction of a Sorter created by
+// [Sorter.WithCounters] is consulted and how often it decides a comparison,
+// that is, finds the elements unequal.  An instruction that is consulted often
+// but rarely decides is a candidate for moving later in the program or for
+// replacing with a more discriminating one.  Counters is safe for concurrent
+// use by multiple goroutines.
+type Counters struct {
+	calls, decisions []atomic.Int64
+}
+
+// Len returns the number of instructions counted.
+func (c *Counters) Len() int { return len(c.calls) }
+
+// Calls returns how often instruction i, counted from 0 in the order of
+// addition, was consulted.
+func (c *Counters) Calls(i int) int64 { return c.calls[i].Load() }
+
+// Decisions returns how often instruction i, counted from 0 in the order of
+// addition, decided a comparison.
+func (c *Counters) Decisions(i int) int64 { return c.decisions[i].Load() }
+
+// Reset sets all counts to zero.
+func (c *Counters) Reset() {
+	for i := range c.calls {
+		c.calls[i].Store(0)
+		c.decisions[i].Store(0)
+	}
+}
+
+// WithCounters returns a copy of the Sorter whose instructions count their use
+// in the returned Counters.  It is a diagnostics tool for tuning the order of
+// instructions, not for production use: counting adds atomic operations to
+// every comparison, and instructions defined with [Sorter.ByFunc] are always
+// called in both directions to tell whether they decided.  Instructions added
+// to the returned Sorter afterwards are not counted.
+func (s *Sorter[T]) WithCounters() (*Sorter[T], *Counters) {
+	c := &Counters{
+		calls:     make([]atomic.Int64, len(s.prog)),
+		decisions: make([]atomic.Int64, len(s.prog)),
+	}
+	cp := *s
+	cp.prog = make([]inst[T], len(s.prog))
+	for i, o := range s.prog {
+		i, orig := i, o
+		count := func(v int) int {
+			c.calls[i].Add(1)
+			if v != 0 {
+				c.decisions[i].Add(1)
+			}
+			return v
+		}
+		o.CmpDir = func(l, r T, d Dir) int { return count(orig.compare(l, r, d)) }
+		if f := orig.CmpCtx; f != nil {
+			o.CmpCtx = func(ctx context.Context, l, r T, d Dir) int { return count(f(ctx, l, r, d)) }
+		}
+		if draw := orig.Draw; draw != nil {
+			o.Draw = func(data []T) func(l, r T, li, ri int) int {
+				f := draw(data)
+				return func(l, r T, li, ri int) int { return count(f(l, r, li, ri)) }
+			}
+		}
+		o.Func, o.Cmp = nil, nil
+		cp.prog[i] = o
+	}
+	return &cp, c
+}
@@ -0,0 +1,55 @@
+package esort
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestWithCounters(t *testing.T) {
+	base := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Desc).
+		ByFunc(func(l, r Data) bool { return l.String < r.String }, Asc)
+	s, c := base.WithCounters()
+	if got, want := c.Len(), 2; got != want {
+		t.Fatalf("c.Len() = %d, want %d", got, want)
+	}
+	for _, test := range []struct {
+		l, r Data
+		want bool
+	}{
+		{Data{Int: 1, String: "a"}, Data{Int: 2, String: "a"}, false},
+		{Data{Int: 1, String: "a"}, Data{Int: 1, String: "b"}, true},
+		{Data{Int: 1, String: "b"}, Data{Int: 1, String: "a"}, false},
+		{Data{Int: 1, String: "a"}, Data{Int: 1, String: "a"}, false},
+	} {
+		if got := s.Less(test.l, test.r); got != test.want {
+			t.Errorf("s.Less(%v, %v) = %v, want %v", test.l, test.r, got, test.want)
+		}
+	}
+	for i, want := range []struct{ calls, decisions int64 }{{4, 1}, {3, 2}} {
+		if got := c.Calls(i); got != want.calls {
+			t.Errorf("c.Calls(%d) = %d, want %d", i, got, want.calls)
+		}
+		if got := c.Decisions(i); got != want.decisions {
+			t.Errorf("c.Decisions(%d) = %d, want %d", i, got, want.decisions)
+		}
+	}
+
+	c.Reset()
+	in := []Data{{Int: 2, String: "b"}, {Int: 1, String: "a"}, {Int: 2, String: "a"}}
+	out := slices.Clone(in)
+	slices.SortFunc(out, s.Less)
+	want := slices.Clone(in)
+	slices.SortFunc(want, base.Less)
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+	}
+	if c.Calls(0) == 0 || c.Decisions(0) == 0 || c.Calls(1) == 0 || c.Decisions(1) == 0 {
+		t.Errorf("counts after sorting are [%d/%d %d/%d], want all non-zero", c.Calls(0), c.Decisions(0), c.Calls(1), c.Decisions(1))
+	}
+	if got, want := c.Calls(0), c.Decisions(0)+c.Calls(1); got != want {
+		t.Errorf("c.Calls(0) = %d, want c.Decisions(0) + c.Calls(1) = %d", got, want)
+	}
+}
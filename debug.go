@@ -0,0 +1,86 @@
+package esort
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDebugElements is the number of elements DebugString prints at most.
+const maxDebugElements = 32
+
+// decide compares l and r like Less and returns the index of the instruction
+// that decided the pair along with its result as -1, 0, or +1 after applying
+// the instruction's direction.  A pair decided by the fallback comparison
+// yields the index len(s.prog).  It returns -1 and 0 if the pair ties.
+func (s *Sorter[T]) decide(l, r T) (i, c int) {
+	for i, f := range s.prog {
+		l, r := l, r
+		if f.Dir == Desc {
+			r, l = l, r
+		}
+		if c := f.compare(l, r, f.Dir); c != 0 {
+			return i, sign(int64(c))
+		}
+	}
+	if s.fallback != nil {
+		if c := s.fallback(l, r); c != 0 {
+			return len(s.prog), sign(int64(c))
+		}
+	}
+	return -1, 0
+}
+
+// DebugString sorts a copy of the data like [Sorter.SortStable] and returns a
+// trace of the result for debugging a surprising order.  Each element is
+// printed on its own line using format, and between adjacent elements a line
+// names the instruction that ordered them by its index, label, and direction,
+// or reports that they tie under all instructions:
+//
+//	[0] alice 30
+//	    < instruction 0 (age:asc)
+//	[1] bob 42
+//	    = tie
+//	[2] bob 42
+//
+// A ">" between elements reveals an inconsistent comparison, which
+// [CheckTotalOrder] can help to track down.  Only the first 32 elements of the
+// sorted copy are printed, followed by a count of the remaining ones.  The
+// data is not modified.
+//
+// The format of the trace is intended for people and may change.
+func (s *Sorter[T]) DebugString(data []T, format func(T) string) string {
+	sorted := append([]T(nil), data...)
+	s.SortStable(sorted)
+	n := len(sorted)
+	if n > maxDebugElements {
+		n = maxDebugElements
+	}
+	var b strings.Builder
+	for j := 0; j < n; j++ {
+		if j > 0 {
+			switch i, c := s.decide(sorted[j-1], sorted[j]); c {
+			case 0:
+				b.WriteString("    = tie\n")
+			default:
+				op := "<"
+				if c > 0 {
+					op = ">"
+				}
+				if i == len(s.prog) {
+					fmt.Fprintf(&b, "    %s fallback\n", op)
+					break
+				}
+				label := s.prog[i].Label
+				if label == "" {
+					label = unlabeled
+				}
+				fmt.Fprintf(&b, "    %s instruction %d (%s:%v)\n", op, i, label, s.prog[i].Dir)
+			}
+		}
+		fmt.Fprintf(&b, "[%d] %s\n", j, format(sorted[j]))
+	}
+	if rest := len(sorted) - n; rest > 0 {
+		fmt.Fprintf(&b, "… %d more elements\n", rest)
+	}
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package esort
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDebugString(t *testing.T) {
+	s := New[Data]().
+		ByString(func(d Data) string { return d.String }, Asc).Label("name").
+		ByInt(func(d Data) int { return d.Int }, Desc)
+	format := func(d Data) string { return fmt.Sprintf("%s %d", d.String, d.Int) }
+	in := []Data{
+		{String: "bob", Int: 1},
+		{String: "carol", Int: 5},
+		{String: "bob", Int: 2},
+		{String: "alice", Int: 7},
+		{String: "bob", Int: 1},
+	}
+	want := `[0] alice 7
+    < instruction 0 (name:asc)
+[1] bob 2
+    < instruction 1 (_:desc)
+[2] bob 1
+    = tie
+[3] bob 1
+    < instruction 0 (name:asc)
+[4] carol 5
+`
+	got := s.DebugString(in, format)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("s.DebugString(%v, …) = %q, want %q\n\ndiff (-want, +got):\n%v", in, got, want, diff)
+	}
+	if in[0].String != "bob" || in[3].String != "alice" {
+		t.Errorf("s.DebugString(…) modified its input: %v", in)
+	}
+}
+
+func TestDebugStringCap(t *testing.T) {
+	s := New[int]().ByInt(func(v int) int { return v }, Asc)
+	in := make([]int, maxDebugElements+3)
+	for i := range in {
+		in[i] = len(in) - i
+	}
+	got := s.DebugString(in, strconv.Itoa)
+	if n := strings.Count(got, "\n["); n != maxDebugElements-1 {
+		t.Errorf("s.DebugString(…) printed %d elements, want %d", n+1, maxDebugElements)
+	}
+	if want := "… 3 more elements\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("s.DebugString(…) = %q, want suffix %q", got, want)
+	}
+}
+
+func TestDebugStringFallback(t *testing.T) {
+	s := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Asc).
+		WithFallback(func(l, r Data) int { return compare(l.String, r.String) })
+	in := []Data{{Int: 1, String: "b"}, {Int: 1, String: "a"}}
+	want := "[0] a\n    < fallback\n[1] b\n"
+	if got := s.DebugString(in, func(d Data) string { return d.String }); got != want {
+		t.Errorf("s.DebugString(%v, …) = %q, want %q", in, got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package esort
+
+import (
+	"math"
+	"math/big"
+)
+
+// sign returns -1, 0, or 1 according to the sign of v.
+func sign(v int64) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
+
+// compareScaled compares the decimals lu×10^-ls and ru×10^-rs exactly by
+// scaling the one with the smaller scale up to the larger scale.
+func compareScaled(lu int64, ls int32, ru int64, rs int32) int {
+	if ls > rs {
+		return -compareScaled(ru, rs, lu, ls)
+	}
+	if lsgn, rsgn := sign(lu), sign(ru); lsgn != rsgn || lsgn == 0 {
+		return compareInt(lsgn, rsgn)
+	}
+	n := int64(rs) - int64(ls)
+	if n >= 19 {
+		// |lu|×10^19 exceeds the magnitude of every int64, so lu decides.
+		return sign(lu)
+	}
+	v := lu
+	for ; n > 0; n-- {
+		if v > math.MaxInt64/10 || v < math.MinInt64/10 {
+			break
+		}
+		v *= 10
+	}
+	if n == 0 {
+		return compareInt(v, ru)
+	}
+	// Scaling overflowed int64.
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+	return p.Mul(p, big.NewInt(v)).Cmp(big.NewInt(ru))
+}
+
+// compareInt compares two integers three-way.
+func compareInt[V int | int64](l, r V) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+// ByScaledInt sorts the data by a given decimal value represented as an
+// unscaled integer and a scale, such as a DECIMAL column from a database:
+// the value is unscaled×10^-scale, so 150 with scale 2 and 15 with scale 1
+// both represent 1.5 and tie.  The values are compared exactly by aligning
+// their scales rather than converting them to floating point.  Alignments
+// that overflow int64 are carried out with [big.Int].  Negative scales are
+// supported and multiply the unscaled value by a power of ten.
+func (s *Sorter[T]) ByScaledInt(unscaled func(T) int64, scale func(T) int32, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareScaled(unscaled(l), scale(l), unscaled(r), scale(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindFloat})
+}
+
+// ByBigFloat sorts the data by a given arbitrary-precision floating-point
+// value, compared exactly with [big.Float.Cmp] at whatever precision the values
+// carry.  Infinities sort below and above all finite values, and -0 and +0
+// tie.  big.Float has no NaN, since operations that would produce one panic
+// with [big.ErrNaN] instead.  Nil values are placed according to nulls and tie
+// with one another.
+func (s *Sorter[T]) ByBigFloat(f func(T) *big.Float, nulls NullPlacement, d Dir) *Sorter[T] {
+	fn := func(l, r T, d Dir) int {
+		lv, rv := f(l), f(r)
+		if less, ok := nullLess(lv == nil, rv == nil, nulls, d); ok {
+			switch {
+			case less:
+				return -1
+			case (lv == nil) == (rv == nil):
+				return 0
+			}
+			return 1
+		}
+		return lv.Cmp(rv)
+	}
+	return s.addInst(inst[T]{CmpDir: fn, Dir: d, Kind: KindFloat})
+}
@@ -0,0 +1,146 @@
+package esort
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestCompareScaled(t *testing.T) {
+	for _, test := range []struct {
+		lu   int64
+		ls   int32
+		ru   int64
+		rs   int32
+		want int
+	}{
+		{150, 2, 15, 1, 0},
+		{15, 1, 150, 2, 0},
+		{151, 2, 15, 1, 1},
+		{-151, 2, -15, 1, -1},
+		{0, 5, 0, -3, 0},
+		{0, 0, -1, 30, 1},
+		{10, 0, math.MaxInt64, 18, 1},
+		{9, 0, math.MaxInt64, 18, -1},
+		{-9, 0, math.MinInt64, 18, 1},
+		{1, -2, 100, 0, 0},
+		{1, -2, 101, 0, -1},
+		{1, 0, math.MaxInt64, 40, 1},
+		{-1, 0, math.MinInt64, 40, -1},
+		{math.MaxInt64, 0, math.MaxInt64, 1, 1},
+		{math.MinInt64, 0, math.MinInt64, 1, -1},
+	} {
+		if got := compareScaled(test.lu, test.ls, test.ru, test.rs); got != test.want {
+			t.Errorf("compareScaled(%d, %d, %d, %d) = %d, want %d", test.lu, test.ls, test.ru, test.rs, got, test.want)
+		}
+		// The comparison must agree with exact rational arithmetic.
+		if got, want := compareScaled(test.lu, test.ls, test.ru, test.rs), ratScaled(test.lu, test.ls).Cmp(ratScaled(test.ru, test.rs)); got != want {
+			t.Errorf("compareScaled(%d, %d, %d, %d) = %d, want %d from big.Rat", test.lu, test.ls, test.ru, test.rs, got, want)
+		}
+	}
+}
+
+// ratScaled returns u×10^-s exactly.
+func ratScaled(u int64, s int32) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(s))), nil)
+	r := new(big.Rat).SetInt64(u)
+	if s > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(p))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(p))
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func TestByScaledInt(t *testing.T) {
+	type amount struct {
+		Text     string
+		Unscaled int64
+		Scale    int32
+	}
+	unscaled := func(a amount) int64 { return a.Unscaled }
+	scale := func(a amount) int32 { return a.Scale }
+	text := func(a amount) string { return a.Text }
+	in := []amount{
+		{"1.50", 150, 2},
+		{"-2", -2, 0},
+		{"1.5", 15, 1},
+		{"1.499", 1499, 3},
+		{"300", 3, -2},
+		{"0.0001", 1, 4},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"-2", "0.0001", "1.499", "1.5", "1.50", "300"}},
+		{"desc", Desc, []string{"300", "1.5", "1.50", "1.499", "0.0001", "-2"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[amount]().ByScaledInt(unscaled, scale, test.d).ByString(text, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, a := range out {
+				got = append(got, a.Text)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByBigFloat(t *testing.T) {
+	parse := func(s string) *big.Float {
+		if s == "nil" {
+			return nil
+		}
+		v, _, err := big.ParseFloat(s, 10, 200, big.ToNearestEven)
+		if err != nil {
+			t.Fatalf("big.ParseFloat(%q) = %v", s, err)
+		}
+		return v
+	}
+	in := []string{"1.000000000000000000000000000002", "+Inf", "nil", "1", "-Inf", "1.000000000000000000000000000001", "-0"}
+	vals := make(map[string]*big.Float)
+	for _, s := range in {
+		vals[s] = parse(s)
+	}
+	if a, _ := vals["1"].Float64(); a != 1 {
+		t.Fatalf("vals[1].Float64() = %v, want 1", a)
+	}
+	if a, _ := vals["1.000000000000000000000000000001"].Float64(); a != 1 {
+		t.Fatalf("high-precision value is distinguishable as float64: %v", a)
+	}
+	f := func(s string) *big.Float { return vals[s] }
+	for _, test := range []struct {
+		name  string
+		nulls NullPlacement
+		d     Dir
+		out   []string
+	}{
+		{"asc nulls first", NullsFirst, Asc, []string{"nil", "-Inf", "-0", "1", "1.000000000000000000000000000001", "1.000000000000000000000000000002", "+Inf"}},
+		{"asc nulls last", NullsLast, Asc, []string{"-Inf", "-0", "1", "1.000000000000000000000000000001", "1.000000000000000000000000000002", "+Inf", "nil"}},
+		{"desc nulls first", NullsFirst, Desc, []string{"nil", "+Inf", "1.000000000000000000000000000002", "1.000000000000000000000000000001", "1", "-0", "-Inf"}},
+		{"desc nulls last", NullsLast, Desc, []string{"+Inf", "1.000000000000000000000000000002", "1.000000000000000000000000000001", "1", "-0", "-Inf", "nil"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByBigFloat(f, test.nulls, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
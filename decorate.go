@@ -0,0 +1,317 @@
+package esort
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// The functions in this file sort data by keys computed in a pre-pass over
+// the data, which a Sorter cannot express because its instructions only ever
+// see the pair of elements being compared.
+
+// sortByKeys stably sorts data by the parallel keys in direction d.  keys is
+// not modified.
+func sortByKeys[T any, K constraints.Ordered](data []T, keys []K, d Dir) {
+	idx := make([]int, len(data))
+	for i := range idx {
+		idx[i] = i
+	}
+	less := func(l, r int) bool { return keys[l] < keys[r] }
+	if d == Desc {
+		less = func(l, r int) bool { return keys[r] < keys[l] }
+	}
+	slices.SortStableFunc(idx, less)
+	permute(data, idx)
+}
+
+// permute reorders data such that data[i] becomes the element previously at
+// data[idx[i]].
+func permute[T any](data []T, idx []int) {
+	out := make([]T, len(data))
+	for i, j := range idx {
+		out[i] = data[j]
+	}
+	copy(data, out)
+}
+
+// SortByFrequency sorts the data in place by how often each element's key, as
+// given by f, occurs in the data.  In ascending order the rarest keys sort
+// first; in descending order the most common ones do.  Elements with equally
+// frequent keys keep their original order.
+func SortByFrequency[T any, K comparable](data []T, f func(T) K, d Dir) {
+	counts := make(map[K]int)
+	keys := make([]K, len(data))
+	for i, v := range data {
+		keys[i] = f(v)
+		counts[keys[i]]++
+	}
+	freq := make([]int, len(data))
+	for i, k := range keys {
+		freq[i] = counts[k]
+	}
+	sortByKeys(data, freq, d)
+}
+
+// SortByScore sorts the data in place by the given score, computing it exactly
+// once per element.  Elements with equal scores keep their original order.
+func SortByScore[T any](data []T, score func(T) float64, d Dir) {
+	SortByKey(data, score, d)
+}
+
+// SortByKey sorts the data in place by the ordered key computed from each
+// element by key, computing it exactly once per element.  This decorated sort
+// pays off over a Sorter with [ByKey] when the key is expensive to compute.
+// Elements with equal keys keep their original order.
+func SortByKey[T any, K constraints.Ordered](data []T, key func(T) K, d Dir) {
+	keys := make([]K, len(data))
+	for i, v := range data {
+		keys[i] = key(v)
+	}
+	sortByKeys(data, keys, d)
+}
+
+// SortByKeys sorts the data in place by the parallel keys, such that data[i]
+// is ordered by keys[i], for keys that were computed ahead of time, such as by
+// a database query.  Elements with equal keys keep their original order.  keys
+// is not modified.  SortByKeys returns an error without modifying data if keys
+// differs in length from data.
+func SortByKeys[T any, K constraints.Ordered](data []T, keys []K, d Dir) error {
+	if len(keys) != len(data) {
+		return fmt.Errorf("esort: %d keys for %d elements", len(keys), len(data))
+	}
+	sortByKeys(data, keys, d)
+	return nil
+}
+
+// SortByMedianDistance sorts the data in place by how far a given numeric
+// value lies from the median of the values across the data, such as to show
+// typical readings first and outliers last in ascending order.  The median is
+// computed in a pre-pass; for an even number of values it is the average of
+// the two middle ones.  Elements equally far from the median, including those
+// on opposite sides of it, keep their original order.
+//
+// The values are converted to float64, so integers beyond 2^53 lose
+// precision.  NaNs are left out of the median and are treated as infinitely
+// far from it.
+func SortByMedianDistance[T any, V constraints.Integer | constraints.Float](data []T, f func(T) V, d Dir) {
+	vals := make([]float64, len(data))
+	var sorted []float64
+	for i, v := range data {
+		vals[i] = float64(f(v))
+		if vals[i] == vals[i] {
+			sorted = append(sorted, vals[i])
+		}
+	}
+	if len(sorted) == 0 {
+		return
+	}
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+	for i, v := range vals {
+		if v != v {
+			vals[i] = math.Inf(1)
+			continue
+		}
+		vals[i] = math.Abs(v - median)
+	}
+	sortByKeys(data, vals, d)
+}
+
+// countingSpanFactor bounds the key range SortCountingInt sorts by counting
+// relative to the length of the data, which keeps its counts from dwarfing
+// the data.
+const countingSpanFactor = 16
+
+// SortCountingInt stably sorts the data in place by a given integer key
+// within the inclusive range [min, max] using a counting sort, which runs in
+// O(n + max - min) time and beats a comparison sort for large data with a
+// small key range.  If any key falls outside of the range, or the range is
+// much wider than the data is long, SortCountingInt falls back to a stable
+// comparison sort.
+func SortCountingInt[T any](data []T, f func(T) int, min, max int, d Dir) {
+	keys := make([]int, len(data))
+	// The span is computed in unsigned arithmetic, where it cannot overflow.
+	inRange := min <= max && uint(max)-uint(min) < countingSpanFactor*uint(len(data)+1)
+	for i, v := range data {
+		keys[i] = f(v)
+		if keys[i] < min || keys[i] > max {
+			inRange = false
+		}
+	}
+	if !inRange {
+		sortByKeys(data, keys, d)
+		return
+	}
+	// start[k] holds the first output position of key k+min once accumulated.
+	start := make([]int, max-min+1)
+	for _, k := range keys {
+		start[k-min]++
+	}
+	pos := 0
+	for i := range start {
+		if d == Desc {
+			i = len(start) - 1 - i
+		}
+		n := start[i]
+		start[i] = pos
+		pos += n
+	}
+	out := make([]T, len(data))
+	for i, k := range keys {
+		out[start[k-min]] = data[i]
+		start[k-min]++
+	}
+	copy(data, out)
+}
+
+// SortRadixBytes stably sorts the data in place by a given byte slice key
+// using a least-significant-digit radix sort, which runs in O(n × w) time for
+// keys of at most w bytes and beats a comparison sort for large data with
+// short, fixed-width keys.  Keys of varying length are ordered as by
+// [bytes.Compare]: a key that is a prefix of another sorts first.  Each pass
+// costs time proportional to the longest key, so a few long keys slow the
+// sort for all.
+func SortRadixBytes[T any](data []T, f func(T) []byte, d Dir) {
+	keys := make([][]byte, len(data))
+	width := 0
+	for i, v := range data {
+		keys[i] = f(v)
+		if len(keys[i]) > width {
+			width = len(keys[i])
+		}
+	}
+	idx := make([]int, len(data))
+	for i := range idx {
+		idx[i] = i
+	}
+	buf := make([]int, len(data))
+	// Bucket 0 holds keys that ended before pos; bucket b+1 holds byte b.
+	var start [257]int
+	for pos := width - 1; pos >= 0; pos-- {
+		bucket := func(i int) int {
+			if pos < len(keys[i]) {
+				return int(keys[i][pos]) + 1
+			}
+			return 0
+		}
+		start = [257]int{}
+		for _, i := range idx {
+			start[bucket(i)]++
+		}
+		n := 0
+		for b := range start {
+			if d == Desc {
+				b = len(start) - 1 - b
+			}
+			c := start[b]
+			start[b] = n
+			n += c
+		}
+		for _, i := range idx {
+			b := bucket(i)
+			buf[start[b]] = i
+			start[b]++
+		}
+		idx, buf = buf, idx
+	}
+	permute(data, idx)
+}
+
+// msdCutoff is the bucket size below which SortBytesKey switches from radix
+// partitioning to insertion sort.
+const msdCutoff = 32
+
+// SortBytesKey stably sorts the data in place by a given byte slice key using
+// a most-significant-digit radix sort.  It suits long keys that share long
+// prefixes, such as paths or composite keys with a common leading component:
+// a comparison sort rescans the shared prefix in each of its O(n log n)
+// comparisons, whereas SortBytesKey skips the prefix shared by a group of keys
+// in a single scan and then partitions them by their next byte.  Small groups
+// are insertion sorted starting after the prefix their keys share.  Keys are
+// ordered as by [bytes.Compare], and f is called once per element.
+//
+// For short keys of uniform length, [SortRadixBytes] is usually faster.
+func SortBytesKey[T any](data []T, f func(T) []byte, d Dir) {
+	keys := make([][]byte, len(data))
+	for i, v := range data {
+		keys[i] = f(v)
+	}
+	idx := make([]int, len(data))
+	for i := range idx {
+		idx[i] = i
+	}
+	sortMSD(keys, idx, make([]int, len(data)), 0, d)
+	permute(data, idx)
+}
+
+// sortMSD stably sorts idx by the keys it indexes, all of which are known to
+// share their first depth bytes.  buf is scratch space as long as idx.
+func sortMSD(keys [][]byte, idx, buf []int, depth int, d Dir) {
+	if len(idx) <= msdCutoff {
+		for i := 1; i < len(idx); i++ {
+			for j := i; j > 0; j-- {
+				c := bytes.Compare(keys[idx[j]][depth:], keys[idx[j-1]][depth:])
+				if c == 0 || (c > 0) == (d == Asc) {
+					break
+				}
+				idx[j], idx[j-1] = idx[j-1], idx[j]
+			}
+		}
+		return
+	}
+	// Skip the prefix shared by all keys at once rather than partitioning
+	// them into a single bucket byte by byte.
+	first := keys[idx[0]]
+	lcp := len(first)
+	for _, i := range idx[1:] {
+		k := keys[i]
+		if len(k) < lcp {
+			lcp = len(k)
+		}
+		j := depth
+		for j < lcp && k[j] == first[j] {
+			j++
+		}
+		lcp = j
+	}
+	depth = lcp
+	// Bucket 0 holds keys that end at depth; bucket b+1 holds byte b.
+	bucket := func(i int) int {
+		if depth < len(keys[i]) {
+			return int(keys[i][depth]) + 1
+		}
+		return 0
+	}
+	var count, end [257]int
+	for _, i := range idx {
+		count[bucket(i)]++
+	}
+	n := 0
+	for b := range count {
+		if d == Desc {
+			b = len(count) - 1 - b
+		}
+		end[b] = n
+		n += count[b]
+	}
+	for _, i := range idx {
+		b := bucket(i)
+		buf[end[b]] = i
+		end[b]++
+	}
+	copy(idx, buf)
+	// The keys in bucket 0 are equal, so only the others need sorting.
+	for b := 1; b < len(count); b++ {
+		if count[b] > 1 {
+			lo, hi := end[b]-count[b], end[b]
+			sortMSD(keys, idx[lo:hi], buf[lo:hi], depth+1, d)
+		}
+	}
+}
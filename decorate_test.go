@@ -0,0 +1,408 @@
+package esort
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/exp/slices"
+)
+
+func TestSortByFrequency(t *testing.T) {
+	in := []string{"b1", "a1", "c1", "b2", "a2", "b3"}
+	first := func(s string) byte { return s[0] }
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"c1", "a1", "a2", "b1", "b2", "b3"}},
+		{"desc", Desc, []string{"b1", "b2", "b3", "a1", "a2", "c1"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			SortByFrequency(out, first, test.d)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("SortByFrequency(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestSortByScore(t *testing.T) {
+	in := []string{"aaa", "b", "cc", "dddd", "e"}
+	var calls int
+	score := func(s string) float64 {
+		calls++
+		return float64(len(s))
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"b", "e", "cc", "aaa", "dddd"}},
+		{"desc", Desc, []string{"dddd", "aaa", "cc", "b", "e"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			calls = 0
+			out := slices.Clone(in)
+			SortByScore(out, score, test.d)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("SortByScore(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+			if got, want := calls, len(in); got != want {
+				t.Errorf("SortByScore(%v) called score %d times, want %d", in, got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkSortByScore(b *testing.B) {
+	const n = 1000
+	in := make([]int, n)
+	for i := range in {
+		in[i] = i * 7919 % n
+	}
+	var calls int
+	score := func(v int) float64 {
+		calls++
+		return float64(v)
+	}
+	for _, bench := range []struct {
+		name string
+		sort func([]int)
+	}{
+		{"cached", func(data []int) { SortByScore(data, score, Desc) }},
+		{"sorter", func(data []int) { slices.SortFunc(data, ByScore(New[int](), score, Desc).Less) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			calls = 0
+			data := make([]int, n)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(data, in)
+				bench.sort(data)
+			}
+			b.ReportMetric(float64(calls)/float64(b.N*n), "scores/elem")
+		})
+	}
+}
+
+func TestSortCountingInt(t *testing.T) {
+	in := []Data{
+		{Int: 2, String: "a"},
+		{Int: 0, String: "b"},
+		{Int: 2, String: "c"},
+		{Int: 1, String: "d"},
+		{Int: 0, String: "e"},
+		{Int: 2, String: "f"},
+	}
+	key := func(d Data) int { return d.Int }
+	asc := []Data{
+		{Int: 0, String: "b"},
+		{Int: 0, String: "e"},
+		{Int: 1, String: "d"},
+		{Int: 2, String: "a"},
+		{Int: 2, String: "c"},
+		{Int: 2, String: "f"},
+	}
+	desc := []Data{
+		{Int: 2, String: "a"},
+		{Int: 2, String: "c"},
+		{Int: 2, String: "f"},
+		{Int: 1, String: "d"},
+		{Int: 0, String: "b"},
+		{Int: 0, String: "e"},
+	}
+	for _, test := range []struct {
+		name     string
+		min, max int
+		d        Dir
+		out      []Data
+	}{
+		{"asc", 0, 2, Asc, asc},
+		{"desc", 0, 2, Desc, desc},
+		{"wide range", -10, 10, Asc, asc},
+		{"fallback asc", 1, 2, Asc, asc},
+		{"fallback desc", 0, 1, Desc, desc},
+		{"full range", math.MinInt, math.MaxInt, Asc, asc},
+		{"huge range", 0, 1 << 40, Desc, desc},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			SortCountingInt(out, key, test.min, test.max, test.d)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("SortCountingInt(%v, %d, %d) = %v, want %v\n\ndiff (-want, +got):\n%v", in, test.min, test.max, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func BenchmarkSortCountingInt(b *testing.B) {
+	const n = 100000
+	in := make([]Data, n)
+	for i := range in {
+		in[i] = Data{Int: i * 7919 % 16}
+	}
+	key := func(d Data) int { return d.Int }
+	s := New[Data]().ByInt(key, Asc)
+	for _, bench := range []struct {
+		name string
+		sort func([]Data)
+	}{
+		{"counting", func(data []Data) { SortCountingInt(data, key, 0, 15, Asc) }},
+		{"comparison", func(data []Data) { slices.SortStableFunc(data, s.Less) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			data := make([]Data, n)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(data, in)
+				bench.sort(data)
+			}
+		})
+	}
+}
+
+func TestSortRadixBytes(t *testing.T) {
+	key := func(d Data) []byte { return d.Bytes }
+	var in []Data
+	for i := 0; i < 1000; i++ {
+		n := i * 7919 % 3 // Keys of length 0, 1, and 2 with many duplicates.
+		b := make([]byte, n)
+		for j := range b {
+			b[j] = byte(i * (j + 31) % 4)
+		}
+		in = append(in, Data{Bytes: b, Int: i})
+	}
+	for _, d := range []Dir{Asc, Desc} {
+		t.Run(d.String(), func(t *testing.T) {
+			want := slices.Clone(in)
+			slices.SortStableFunc(want, New[Data]().ByBytes(key, d).Less)
+			got := slices.Clone(in)
+			SortRadixBytes(got, key, d)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("SortRadixBytes(…) differs from stable comparison sort\n\ndiff (-want, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func BenchmarkSortRadixBytes(b *testing.B) {
+	const n = 1000000
+	in := make([]Data, n)
+	for i := range in {
+		in[i].Bytes = make([]byte, 8)
+		binary.BigEndian.PutUint64(in[i].Bytes, uint64(i)*0x9e3779b97f4a7c15)
+	}
+	key := func(d Data) []byte { return d.Bytes }
+	s := New[Data]().ByBytes(key, Asc)
+	for _, bench := range []struct {
+		name string
+		sort func([]Data)
+	}{
+		{"radix", func(data []Data) { SortRadixBytes(data, key, Asc) }},
+		{"comparison", func(data []Data) { slices.SortFunc(data, s.Less) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			data := make([]Data, n)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(data, in)
+				bench.sort(data)
+			}
+		})
+	}
+}
+
+func TestSortBytesKey(t *testing.T) {
+	key := func(d Data) []byte { return d.Bytes }
+	var in []Data
+	for i := 0; i < 2000; i++ {
+		// Keys share prefixes of varying length and contain many duplicates,
+		// so buckets of all sizes are exercised.
+		b := bytes.Repeat([]byte{'p'}, i%5*10)
+		for j := 0; j < i*7919%4; j++ {
+			b = append(b, byte(i*(j+31)%3))
+		}
+		in = append(in, Data{Bytes: b, Int: i})
+	}
+	for _, d := range []Dir{Asc, Desc} {
+		t.Run(d.String(), func(t *testing.T) {
+			want := slices.Clone(in)
+			slices.SortStableFunc(want, New[Data]().ByBytes(key, d).Less)
+			got := slices.Clone(in)
+			SortBytesKey(got, key, d)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("SortBytesKey(…) differs from stable comparison sort\n\ndiff (-want, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func BenchmarkSortBytesKey(b *testing.B) {
+	const n = 1000000
+	prefix := bytes.Repeat([]byte{0xab}, 100)
+	in := make([]Data, n)
+	for i := range in {
+		in[i].Bytes = binary.BigEndian.AppendUint64(slices.Clip(prefix), uint64(i)*0x9e3779b97f4a7c15)
+	}
+	key := func(d Data) []byte { return d.Bytes }
+	s := New[Data]().ByBytes(key, Asc)
+	for _, bench := range []struct {
+		name string
+		sort func([]Data)
+	}{
+		{"msd", func(data []Data) { SortBytesKey(data, key, Asc) }},
+		{"comparison", func(data []Data) { slices.SortFunc(data, s.Less) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			data := make([]Data, n)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(data, in)
+				bench.sort(data)
+			}
+		})
+	}
+}
+
+func TestSortByMedianDistance(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		in   []float64
+		d    Dir
+		out  []float64
+	}{
+		{"odd", []float64{100, 4, -50, 5, 6}, Asc, []float64{5, 4, 6, -50, 100}},
+		{"even", []float64{1, 10, 4, 6}, Asc, []float64{4, 6, 1, 10}},
+		{"desc", []float64{1, 10, 4, 6}, Desc, []float64{10, 1, 4, 6}},
+		{"nan", []float64{math.NaN(), 7, 1, 4}, Asc, []float64{4, 7, 1, math.NaN()}},
+		{"empty", nil, Asc, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(test.in)
+			SortByMedianDistance(out, func(v float64) float64 { return v }, test.d)
+			if diff := cmp.Diff(test.out, out, cmpopts.EquateNaNs()); diff != "" {
+				t.Errorf("SortByMedianDistance(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestSortByMedianDistanceInt(t *testing.T) {
+	in := []int{20, 11, 9, 10, 0}
+	out := slices.Clone(in)
+	SortByMedianDistance(out, func(v int) int { return v }, Asc)
+	if want := []int{10, 11, 9, 20, 0}; !slices.Equal(out, want) {
+		t.Errorf("SortByMedianDistance(%v) = %v, want %v", in, out, want)
+	}
+}
+
+func TestSortByKey(t *testing.T) {
+	in := []string{"b", "dd", "a", "ccc", "e"}
+	var calls int
+	key := func(s string) int {
+		calls++
+		return len(s)
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"b", "a", "e", "dd", "ccc"}},
+		{"desc", Desc, []string{"ccc", "dd", "b", "a", "e"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			calls = 0
+			out := slices.Clone(in)
+			SortByKey(out, key, test.d)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("SortByKey(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+			if got, want := calls, len(in); got != want {
+				t.Errorf("SortByKey(%v) called key %d times, want %d", in, got, want)
+			}
+			want := slices.Clone(in)
+			slices.SortStableFunc(want, ByKey(New[string](), key, test.d).Less)
+			if diff := cmp.Diff(want, out); diff != "" {
+				t.Errorf("SortByKey(%v) differs from ByKey\n\ndiff (-want, +got):\n%v", in, diff)
+			}
+		})
+	}
+}
+
+func TestSortByKeys(t *testing.T) {
+	in := []string{"c", "a", "b", "d"}
+	keys := []float64{3, 1, 2, 1}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"a", "d", "b", "c"}},
+		{"desc", Desc, []string{"c", "b", "a", "d"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			if err := SortByKeys(out, keys, test.d); err != nil {
+				t.Fatalf("SortByKeys(%v, %v) = %v, want nil", in, keys, err)
+			}
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("SortByKeys(%v, %v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, keys, out, test.out, diff)
+			}
+			if diff := cmp.Diff([]float64{3, 1, 2, 1}, keys); diff != "" {
+				t.Errorf("SortByKeys(%v, %v) modified keys\n\ndiff (-want, +got):\n%v", in, keys, diff)
+			}
+		})
+	}
+}
+
+func TestSortByKeysLengthMismatch(t *testing.T) {
+	in := []string{"b", "a"}
+	out := slices.Clone(in)
+	if err := SortByKeys(out, []int{2, 1, 0}, Asc); err == nil {
+		t.Errorf("SortByKeys(%v, [2 1 0]) = nil, want error", in)
+	}
+	if diff := cmp.Diff(in, out); diff != "" {
+		t.Errorf("SortByKeys(%v, [2 1 0]) modified data\n\ndiff (-want, +got):\n%v", in, diff)
+	}
+}
+
+func BenchmarkSortByKey(b *testing.B) {
+	const n = 1000
+	in := make([]string, n)
+	for i := range in {
+		in[i] = fmt.Sprint(i * 7919 % n)
+	}
+	var calls int
+	key := func(s string) string {
+		calls++
+		return strings.ToLower(s)
+	}
+	for _, bench := range []struct {
+		name string
+		sort func([]string)
+	}{
+		{"cached", func(data []string) { SortByKey(data, key, Asc) }},
+		{"sorter", func(data []string) { slices.SortFunc(data, ByKey(New[string](), key, Asc).Less) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			calls = 0
+			data := make([]string, n)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(data, in)
+				bench.sort(data)
+			}
+			b.ReportMetric(float64(calls)/float64(b.N*n), "keys/elem")
+		})
+	}
+}
@@ -137,15 +137,44 @@ package esort
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 
 	"golang.org/x/exp/constraints"
 )
 
-// inst is a sorting operation instruction.
+// inst is a sorting operation instruction.  Exactly one of Func, Cmp, and
+// CmpDir is set.
 type inst[T any] struct {
 	Func func(l, r T) bool
-	Dir  Dir
+	// Cmp is the three-way form of Func, which decides an instruction in one
+	// call rather than two when the instruction is not the last one.
+	Cmp func(l, r T) int
+	// CmpDir is the form of Cmp for instructions that place some values
+	// regardless of direction, such as nils under a NullPlacement.  Like Cmp,
+	// it receives the operands swapped for a descending instruction, and it
+	// also receives the direction d they were swapped for, so the placement
+	// follows the instruction through SetDirections and the like.
+	CmpDir func(l, r T, d Dir) int
+	// CmpCtx, if set, is the context-aware form of CmpDir, which SortContext
+	// binds to its context.  The other comparison functions then call it with
+	// context.Background.
+	CmpCtx func(ctx context.Context, l, r T, d Dir) int
+	// Draw, if set, prepares the instruction for sorting data, such as by
+	// drawing random keys, and returns a comparison that also receives the
+	// positions of l and r in data.  SortStable binds the result to CmpAt,
+	// which orders the pairs that tie under the other comparison functions.
+	Draw func(data []T) func(l, r T, li, ri int) int
+	// CmpAt is the result of Draw for the data being sorted.
+	CmpAt func(l, r T, li, ri int) int
+	Dir   Dir
+	// Stable breaks ties under Func by original position in SortStable.
+	Stable bool
+	// Label names the instruction for MarshalText.
+	Label string
+	// Kind classifies the values the instruction compares for ReverseKind.
+	Kind Kind
 }
 
 // Sorter is the representation of a compound sorting program.  A Sorter is
@@ -155,6 +184,13 @@ type Sorter[T any] struct {
 	// unconventional for Go, its use here is safe and ergonomic, because there
 	// is zero need to consider error handling in this API.
 	prog []inst[T]
+	// recoverPanics makes Sort and SortE recover panics from the
+	// instructions.
+	recoverPanics bool
+	// parallelUnsafe makes SortParallel sort sequentially.
+	parallelUnsafe bool
+	// fallback, if set, orders pairs that tie under all of prog.
+	fallback func(l, r T) int
 }
 
 // Dir represents the direction for the sort.
@@ -167,136 +203,206 @@ const (
 	Desc
 )
 
+// String returns "asc" or "desc".
+func (d Dir) String() string {
+	switch d {
+	case Asc:
+		return "asc"
+	case Desc:
+		return "desc"
+	}
+	return fmt.Sprintf("Dir(%d)", int(d))
+}
+
 // New creates a Sorter.
 func New[T any]() *Sorter[T] { return new(Sorter[T]) }
 
 // addInst copies the existing sorting program and adds a new instruction to
 // the copy.  The copy semantic is used to keep each Sorter safe for use in
 // multiple goroutines and to enable basic templatization of the programs to be
-// performed à la the builder pattern.
+// performed à la the builder pattern.  The copy is made even when s.prog has
+// spare capacity, because Sorters derived concurrently from the same Sorter
+// would otherwise write their instructions to the same backing array.
 func (s *Sorter[T]) addInst(o inst[T]) *Sorter[T] {
-	return &Sorter[T]{
-		prog: append(append([]inst[T](nil), s.prog...), o),
+	c := *s
+	c.prog = append(append([]inst[T](nil), s.prog...), o)
+	return &c
+}
+
+// compare compares l and r three-way according to the instruction for
+// operands already swapped for direction d, which only CmpDir consults.
+func (o inst[T]) compare(l, r T, d Dir) int {
+	switch {
+	case o.CmpDir != nil:
+		return o.CmpDir(l, r, d)
+	case o.Cmp != nil:
+		return o.Cmp(l, r)
+	}
+	switch {
+	case o.Func(l, r):
+		return -1
+	case o.Func(r, l):
+		return 1
+	}
+	return 0
+}
+
+// compareBoolFunc sorts boolean data with false before true.
+func compareBoolFunc[T any](f func(T) bool) func(l, r T) int {
+	return func(l, r T) int {
+		switch lv, rv := f(l), f(r); {
+		case !lv && rv:
+			return -1
+		case lv && !rv:
+			return 1
+		}
+		return 0
 	}
 }
 
 // ByBool sorts the data by a given boolean value.
 func (s *Sorter[T]) ByBool(f func(T) bool, d Dir) *Sorter[T] {
-	fn := func(l, r T) bool {
-		return !f(l) && f(r)
-	}
-	return s.addInst(inst[T]{fn, d})
+	fn := compareBoolFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindBool})
 }
 
-// lessFunc sorts any ordered data.
-func lessFunc[T any, V constraints.Ordered](f func(T) V) func(l, r T) bool {
-	return func(l, r T) bool {
-		return f(l) < f(r)
+// compareFunc sorts any ordered data.
+func compareFunc[T any, V constraints.Ordered](f func(T) V) func(l, r T) int {
+	return func(l, r T) int {
+		lv, rv := f(l), f(r)
+		switch {
+		case lv < rv:
+			return -1
+		case rv < lv:
+			return 1
+		}
+		return 0
 	}
 }
 
 // ByInt8 sorts the data by a given int8 value.
 func (s *Sorter[T]) ByInt8(f func(T) int8, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByInt16 sorts the data by a given int16 value.
 func (s *Sorter[T]) ByInt16(f func(T) int16, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByInt32 sorts the data by a given int32 value.
 func (s *Sorter[T]) ByInt32(f func(T) int32, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByInt64 sorts the data by a given int64 value.
 func (s *Sorter[T]) ByInt64(f func(T) int64, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByUint8 sorts the data by a given uint8 value.
 func (s *Sorter[T]) ByUint8(f func(T) uint8, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByUint16 sorts the data by a given uint16 value.
 func (s *Sorter[T]) ByUint16(f func(T) uint16, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByUint32 sorts the data by a given uint32 value.
 func (s *Sorter[T]) ByUint32(f func(T) uint32, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByUint64 sorts the data by a given uint64 value.
 func (s *Sorter[T]) ByUint64(f func(T) uint64, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByFloat32 sorts the data by a given float32 value.
 func (s *Sorter[T]) ByFloat32(f func(T) float32, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindFloat})
 }
 
 // ByFloat64 sorts the data by a given float64 value.
 func (s *Sorter[T]) ByFloat64(f func(T) float64, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindFloat})
 }
 
 // ByByte sorts the data by a given byte value.
 func (s *Sorter[T]) ByByte(f func(T) byte, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByRune sorts the data by a given rune value.
 func (s *Sorter[T]) ByRune(f func(T) rune, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByUint sorts the data by a given uint value.
 func (s *Sorter[T]) ByUint(f func(T) uint, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByInt sorts the data by a given int value.
 func (s *Sorter[T]) ByInt(f func(T) int, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByPointer sorts the data by a given uintptr value.
 func (s *Sorter[T]) ByPointer(f func(T) uintptr, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindInteger})
 }
 
 // ByString sorts the data by a given string value.
 func (s *Sorter[T]) ByString(f func(T) string, d Dir) *Sorter[T] {
-	fn := lessFunc(f)
-	return s.addInst(inst[T]{fn, d})
+	fn := compareFunc(f)
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
 }
 
 // ByBytes sorts the data by a given byte slice value.
 func (s *Sorter[T]) ByBytes(f func(T) []byte, d Dir) *Sorter[T] {
-	fn := func(l, r T) bool {
-		return bytes.Compare(f(l), f(r)) < 0
+	fn := func(l, r T) int {
+		return bytes.Compare(f(l), f(r))
 	}
-	return s.addInst(inst[T]{fn, d})
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindBytes})
+}
+
+// ByStringField sorts the data by a given string value in ascending order.
+// Follow it with [Sorter.Descending] to reverse it, which suits chains that
+// name the direction last:
+//
+//	sorter := esort.New[Person]().ByStringField(func(p Person) string { return p.GivenName }).Descending()
+func (s *Sorter[T]) ByStringField(f func(T) string) *Sorter[T] {
+	return s.ByString(f, Asc)
+}
+
+// ByIntField sorts the data by a given int value in ascending order.  Follow
+// it with [Sorter.Descending] to reverse it.
+func (s *Sorter[T]) ByIntField(f func(T) int) *Sorter[T] {
+	return s.ByInt(f, Asc)
+}
+
+// ByFloat64Field sorts the data by a given float64 value in ascending order
+// like [Sorter.ByFloat64].  Follow it with [Sorter.Descending] to reverse it.
+func (s *Sorter[T]) ByFloat64Field(f func(T) float64) *Sorter[T] {
+	return s.ByFloat64(f, Asc)
 }
 
 // SortFunc sorts the data according to an arbitrary function.
@@ -332,7 +438,145 @@ type SortFunc[T any] func(l, r T) bool
 // The SortFunc must not the underlying data by that any pre-existing
 // intruction does.
 func (s *Sorter[T]) ByFunc(f SortFunc[T], d Dir) *Sorter[T] {
-	return s.addInst(inst[T]{f, d})
+	return s.addInst(inst[T]{Func: f, Dir: d, Kind: KindFunc})
+}
+
+// ByFuncStable sorts the data according to an arbitrary given [SortFunc] like
+// [Sorter.ByFunc].  When the data is sorted with [Sorter.SortStable], elements
+// that tie under the SortFunc keep their original input order, and no later
+// instruction is consulted for them.  Other sorting functions treat it exactly
+// like ByFunc.
+func (s *Sorter[T]) ByFuncStable(f SortFunc[T], d Dir) *Sorter[T] {
+	return s.addInst(inst[T]{Func: f, Dir: d, Kind: KindFunc, Stable: true})
+}
+
+// SameShape reports whether s and other have the same number of instructions
+// with matching directions.
+//
+// Functions in Go are not comparable, so SameShape cannot tell whether two
+// instructions compare the same thing.  Sorters that sort by different values
+// in the same directions are reported as having the same shape.
+func (s *Sorter[T]) SameShape(other *Sorter[T]) bool {
+	if len(s.prog) != len(other.prog) {
+		return false
+	}
+	for i := range s.prog {
+		if s.prog[i].Dir != other.prog[i].Dir {
+			return false
+		}
+	}
+	return true
+}
+
+// NegateIf returns a copy of the Sorter with the direction of instruction i,
+// counted from 0 in the order of addition, reversed if cond is true, and s
+// itself otherwise.  It suits toggling a criterion between "higher is better"
+// and "lower is better" with a flag, such as in an experiment:
+//
+//	sorter := esort.New[Result]().
+//		ByFloat64(func(r Result) float64 { return r.Score }, esort.Desc).
+//		ByInt(func(r Result) int { return r.Price }, esort.Asc).
+//		NegateIf(1, experiment.PreferExpensive)
+//
+// Values that the instruction places regardless of direction, such as nils
+// under a [NullPlacement], stay where they are.  NegateIf panics if i is out of
+// range.
+func (s *Sorter[T]) NegateIf(i int, cond bool) *Sorter[T] {
+	if i < 0 || i >= len(s.prog) {
+		panic(fmt.Errorf("esort: instruction %d out of range [0, %d)", i, len(s.prog)))
+	}
+	if !cond {
+		return s
+	}
+	c := *s
+	c.prog = append([]inst[T](nil), s.prog...)
+	if c.prog[i].Dir == Desc {
+		c.prog[i].Dir = Asc
+	} else {
+		c.prog[i].Dir = Desc
+	}
+	return &c
+}
+
+// ReplaceFunc returns a copy of the Sorter with the comparison of instruction
+// i, counted from 0 in the order of addition, replaced by f, which follows the
+// contract of [SortFunc].  The direction, label, and other properties of the
+// instruction are kept, so a template's structure can be reused with a
+// different value:
+//
+//	byDisplayName := base.ReplaceFunc(0, func(l, r Person) bool { return l.DisplayName < r.DisplayName })
+//
+// The instruction's [Kind] becomes [KindFunc].  ReplaceFunc panics if i is out
+// of range.
+func (s *Sorter[T]) ReplaceFunc(i int, f SortFunc[T]) *Sorter[T] {
+	if i < 0 || i >= len(s.prog) {
+		panic(fmt.Errorf("esort: instruction %d out of range [0, %d)", i, len(s.prog)))
+	}
+	c := *s
+	c.prog = append([]inst[T](nil), s.prog...)
+	c.prog[i].Func, c.prog[i].Cmp, c.prog[i].CmpDir, c.prog[i].CmpCtx = f, nil, nil, nil
+	c.prog[i].Draw, c.prog[i].CmpAt = nil, nil
+	c.prog[i].Kind = KindFunc
+	return &c
+}
+
+// withLastDir returns a copy of the Sorter with the direction of the most
+// recently added instruction set to d.  It panics if the Sorter has no
+// instructions.
+func (s *Sorter[T]) withLastDir(d Dir) *Sorter[T] {
+	if len(s.prog) == 0 {
+		panic(errNoProgram)
+	}
+	c := *s
+	c.prog = append([]inst[T](nil), s.prog...)
+	c.prog[len(c.prog)-1].Dir = d
+	return &c
+}
+
+// Ascending returns a copy of the Sorter with the most recently added
+// instruction sorting in ascending order, whatever direction it was added
+// with.  Like [Sorter.Label], it panics if the Sorter has no instructions.
+func (s *Sorter[T]) Ascending() *Sorter[T] { return s.withLastDir(Asc) }
+
+// Descending returns a copy of the Sorter with the most recently added
+// instruction sorting in descending order, whatever direction it was added
+// with.  Like [Sorter.Label], it panics if the Sorter has no instructions.
+func (s *Sorter[T]) Descending() *Sorter[T] { return s.withLastDir(Desc) }
+
+// SetDirections returns a copy of the Sorter with the direction of each
+// instruction i set to dirs[i], so that one base program can be reused with
+// different direction vectors, such as from an experiment configuration:
+//
+//	sorter := base.SetDirections([]esort.Dir{esort.Desc, esort.Asc})
+//
+// SetDirections panics if dirs does not have one direction per instruction.
+func (s *Sorter[T]) SetDirections(dirs []Dir) *Sorter[T] {
+	if len(dirs) != len(s.prog) {
+		panic(fmt.Errorf("esort: %d directions for %d instructions", len(dirs), len(s.prog)))
+	}
+	c := *s
+	c.prog = append([]inst[T](nil), s.prog...)
+	for i, d := range dirs {
+		c.prog[i].Dir = d
+	}
+	return &c
+}
+
+// WithFallback returns a copy of the Sorter that orders pairs of elements that
+// tie under all of its instructions by f, a three-way comparison function like
+// that of [Sorter.ByFuncCmp], such as one comparing a cheap identity or a
+// serialized form to make the order total for deduplication or grouping.  f
+// stays last even when instructions are added afterwards, and it is not
+// affected by the instructions' directions.  A later call to WithFallback
+// replaces f.
+//
+// [Sorter.SortStable] consults f before the original positions, except after
+// a tie under an instruction added with [Sorter.ByFuncStable].  [Sorter.Less]
+// is slightly slower on a Sorter with a fallback.
+func (s *Sorter[T]) WithFallback(f func(l, r T) int) *Sorter[T] {
+	c := *s
+	c.fallback = f
+	return &c
 }
 
 // errNoProgram indicates that the sorter has no recorded instructions, meaning
@@ -342,11 +586,20 @@ var errNoProgram = errors.New("esort: no sorting instructions provided")
 // Less is a sort ordering function that fulfills the contract expected by
 // [sort.Interface.Less] and related APIs.
 func (s *Sorter[T]) Less(l, r T) bool {
+	if s.fallback != nil {
+		return s.Compare(l, r) < 0
+	}
 	for i, f := range s.prog {
 		l, r := l, r // Reset original ordering upon more than one cycle.
 		if f.Dir == Desc {
 			r, l = l, r
 		}
+		if f.Func == nil {
+			if c := f.compare(l, r, f.Dir); c != 0 || i == len(s.prog)-1 {
+				return c < 0
+			}
+			continue
+		}
 		switch i {
 		case len(s.prog) - 1:
 			return f.Func(l, r)
@@ -360,3 +613,55 @@ func (s *Sorter[T]) Less(l, r T) bool {
 	}
 	panic(errNoProgram)
 }
+
+// Greater reports whether l sorts after r.  It is equivalent to s.Less(r, l)
+// and suits APIs that expect a reverse ordering, such as a max-heap, without
+// deriving a separate Sorter with reversed instructions.
+func (s *Sorter[T]) Greater(l, r T) bool {
+	return s.Less(r, l)
+}
+
+// Equal reports whether l and r sort equally, that is, neither sorts before
+// the other.
+func (s *Sorter[T]) Equal(l, r T) bool {
+	return !s.Less(l, r) && !s.Less(r, l)
+}
+
+// Compare compares l and r three-way according to the Sorter: it returns -1
+// if l sorts before r, +1 if l sorts after r, and 0 if they sort equally.  It
+// fulfills the contract of [slices.SortFunc] in Go 1.21 and later, even for
+// instructions added with [Sorter.ByFuncCmp] whose function returns other
+// magnitudes.  Compare evaluates each instruction at most once per call,
+// whereas Equal calls Less twice, except that the less function of an
+// instruction added with [Sorter.ByFunc] may be called twice to detect a tie.
+// Compare panics if the Sorter has no instructions.
+//
+// [slices.SortFunc]: https://pkg.go.dev/slices#SortFunc
+func (s *Sorter[T]) Compare(l, r T) int {
+	if len(s.prog) == 0 {
+		panic(errNoProgram)
+	}
+	_, c := s.decide(l, r)
+	return c
+}
+
+// AsLessFunc returns [Sorter.Less] as a function value for APIs that take a
+// less function.  Unlike the method value s.Less, which panics on its first
+// call if the Sorter has no instructions, AsLessFunc panics immediately,
+// which surfaces a misconfigured Sorter where it is handed over.
+func (s *Sorter[T]) AsLessFunc() func(a, b T) bool {
+	if len(s.prog) == 0 {
+		panic(errNoProgram)
+	}
+	return s.Less
+}
+
+// AsCompareFunc returns [Sorter.Compare] as a function value for APIs that
+// take a three-way comparison function.  Like [Sorter.AsLessFunc], it panics
+// immediately if the Sorter has no instructions.
+func (s *Sorter[T]) AsCompareFunc() func(a, b T) int {
+	if len(s.prog) == 0 {
+		panic(errNoProgram)
+	}
+	return s.Compare
+}
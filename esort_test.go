@@ -3,6 +3,7 @@ package esort
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -370,6 +371,556 @@ func TestEmpty(t *testing.T) {
 	}
 }
 
+func TestSameShape(t *testing.T) {
+	byInt := func(d Data) int { return d.Int }
+	byString := func(d Data) string { return d.String }
+	for _, test := range []struct {
+		name string
+		l, r *Sorter[Data]
+		want bool
+	}{
+		{
+			name: "empty",
+			l:    New[Data](),
+			r:    New[Data](),
+			want: true,
+		},
+		{
+			name: "same instructions",
+			l:    New[Data]().ByInt(byInt, Asc).ByString(byString, Desc),
+			r:    New[Data]().ByInt(byInt, Asc).ByString(byString, Desc),
+			want: true,
+		},
+		{
+			name: "different functions same directions",
+			l:    New[Data]().ByInt(byInt, Asc).ByString(byString, Desc),
+			r:    New[Data]().ByString(byString, Asc).ByInt(byInt, Desc),
+			want: true,
+		},
+		{
+			name: "different directions",
+			l:    New[Data]().ByInt(byInt, Asc).ByString(byString, Desc),
+			r:    New[Data]().ByInt(byInt, Asc).ByString(byString, Asc),
+			want: false,
+		},
+		{
+			name: "different lengths",
+			l:    New[Data]().ByInt(byInt, Asc),
+			r:    New[Data]().ByInt(byInt, Asc).ByString(byString, Asc),
+			want: false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.l.SameShape(test.r); got != test.want {
+				t.Errorf("l.SameShape(r) = %v, want %v", got, test.want)
+			}
+			if got := test.r.SameShape(test.l); got != test.want {
+				t.Errorf("r.SameShape(l) = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGreater(t *testing.T) {
+	s := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Asc).
+		ByString(func(d Data) string { return d.String }, Desc)
+	data := []Data{
+		{Int: 0, String: "a"},
+		{Int: 0, String: "b"},
+		{Int: 1, String: "a"},
+		{Int: 1, String: "a"},
+	}
+	for _, l := range data {
+		for _, r := range data {
+			if got, want := s.Greater(l, r), s.Less(r, l); got != want {
+				t.Errorf("s.Greater(%v, %v) = %v, want %v", l, r, got, want)
+			}
+		}
+	}
+}
+
+func TestConcurrentDerivation(t *testing.T) {
+	// base has spare capacity, so an addInst that appended to it in place
+	// would let the derived Sorters overwrite one another's instructions.
+	base := New[Data]().
+		ByBool(func(Data) bool { return false }, Asc).
+		ByBool(func(Data) bool { return false }, Asc).
+		ByBool(func(Data) bool { return false }, Asc)
+	const n = 64
+	derived := make([]*Sorter[Data], n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Odd goroutines sort by Int descending and even ones by String
+			// ascending.
+			if i%2 == 1 {
+				derived[i] = base.ByInt(func(d Data) int { return d.Int }, Desc)
+				return
+			}
+			derived[i] = base.ByString(func(d Data) string { return d.String }, Asc)
+		}()
+	}
+	wg.Wait()
+
+	if got, want := len(base.prog), 3; got != want {
+		t.Fatalf("len(base.prog) = %d, want %d", got, want)
+	}
+	in := []Data{{Int: 1, String: "a"}, {Int: 2, String: "b"}}
+	for i, s := range derived {
+		want := []Data{{Int: 1, String: "a"}, {Int: 2, String: "b"}}
+		if i%2 == 1 {
+			want = []Data{{Int: 2, String: "b"}, {Int: 1, String: "a"}}
+		}
+		got := slices.Clone(in)
+		slices.SortFunc(got, s.Less)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("slices.SortFunc(%v) with derived[%d] = %v, want %v\n\ndiff (-want, +got):\n%v", in, i, got, want, diff)
+		}
+	}
+}
+
+func TestNegateIf(t *testing.T) {
+	base := New[Data]().
+		ByBool(func(d Data) bool { return d.Bool }, Asc).
+		ByInt(func(d Data) int { return d.Int }, Desc).
+		ByString(func(d Data) string { return d.String }, Asc)
+	in := []Data{
+		{Bool: true, Int: 1, String: "a"},
+		{Bool: false, Int: 1, String: "b"},
+		{Bool: false, Int: 2, String: "a"},
+		{Bool: false, Int: 1, String: "a"},
+	}
+	for _, test := range []struct {
+		name string
+		cond bool
+		out  []Data
+	}{
+		{
+			name: "false",
+			cond: false,
+			out: []Data{
+				{Bool: false, Int: 2, String: "a"},
+				{Bool: false, Int: 1, String: "a"},
+				{Bool: false, Int: 1, String: "b"},
+				{Bool: true, Int: 1, String: "a"},
+			},
+		},
+		{
+			name: "true",
+			cond: true,
+			out: []Data{
+				{Bool: false, Int: 1, String: "a"},
+				{Bool: false, Int: 1, String: "b"},
+				{Bool: false, Int: 2, String: "a"},
+				{Bool: true, Int: 1, String: "a"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := base.NegateIf(1, test.cond)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+	if got, want := base.prog[1].Dir, Desc; got != want {
+		t.Errorf("base.prog[1].Dir after NegateIf = %v, want %v", got, want)
+	}
+}
+
+func TestNegateIfOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NegateIf(1, false) on a Sorter with one instruction did not panic")
+		}
+	}()
+	New[Data]().ByInt(func(d Data) int { return d.Int }, Asc).NegateIf(1, false)
+}
+
+func TestEqual(t *testing.T) {
+	s := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Desc).
+		ByString(func(d Data) string { return d.String }, Asc)
+	for _, test := range []struct {
+		l, r Data
+		want bool
+	}{
+		{Data{Int: 1, String: "a"}, Data{Int: 1, String: "a"}, true},
+		{Data{Int: 1, String: "a", Uint: 1}, Data{Int: 1, String: "a", Uint: 2}, true},
+		{Data{Int: 1, String: "a"}, Data{Int: 1, String: "b"}, false},
+		{Data{Int: 2, String: "a"}, Data{Int: 1, String: "a"}, false},
+	} {
+		if got := s.Equal(test.l, test.r); got != test.want {
+			t.Errorf("s.Equal(%v, %v) = %v, want %v", test.l, test.r, got, test.want)
+		}
+	}
+}
+
+func TestReplaceFunc(t *testing.T) {
+	base := New[Data]().
+		ByBool(func(d Data) bool { return d.Bool }, Asc).
+		ByInt(func(d Data) int { return d.Int }, Desc).Label("key").
+		ByString(func(d Data) string { return d.String }, Asc)
+	s := base.ReplaceFunc(1, func(l, r Data) bool { return l.Uint < r.Uint })
+	in := []Data{
+		{Bool: true, Int: 9, Uint: 9},
+		{Int: 1, Uint: 2, String: "a"},
+		{Int: 2, Uint: 1, String: "b"},
+		{Int: 3, Uint: 2, String: "c"},
+	}
+	want := []Data{
+		{Int: 1, Uint: 2, String: "a"},
+		{Int: 3, Uint: 2, String: "c"},
+		{Int: 2, Uint: 1, String: "b"},
+		{Bool: true, Int: 9, Uint: 9},
+	}
+	out := slices.Clone(in)
+	slices.SortFunc(out, s.Less)
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+	}
+	if got, want := s.prog[1].Label, "key"; got != want {
+		t.Errorf("s.prog[1].Label = %q, want %q", got, want)
+	}
+	if !s.SameShape(base) {
+		t.Error("s.SameShape(base) = false, want true")
+	}
+	// base is unaffected.
+	wantBase := []Data{
+		{Int: 3, Uint: 2, String: "c"},
+		{Int: 2, Uint: 1, String: "b"},
+		{Int: 1, Uint: 2, String: "a"},
+		{Bool: true, Int: 9, Uint: 9},
+	}
+	out = slices.Clone(in)
+	slices.SortFunc(out, base.Less)
+	if diff := cmp.Diff(wantBase, out); diff != "" {
+		t.Errorf("slices.SortFunc(%v) with base = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, wantBase, diff)
+	}
+}
+
+func TestReplaceFuncOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ReplaceFunc(-1, …) did not panic")
+		}
+	}()
+	New[Data]().ByInt(func(d Data) int { return d.Int }, Asc).ReplaceFunc(-1, func(l, r Data) bool { return false })
+}
+
+func TestAscendingDescending(t *testing.T) {
+	// nullIfZero makes the strings of elements with a zero Int absent.
+	nullIfZero := func(d Data) *string {
+		if d.Int == 0 {
+			return nil
+		}
+		return &d.String
+	}
+	in := []Data{
+		{Int: 1, String: "a"},
+		{Int: 0, String: "b"},
+		{Int: 1, String: "b"},
+		{Int: 0, String: "a"},
+	}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		out  []Data
+	}{
+		{
+			name: "descending last",
+			s: New[Data]().
+				ByIntField(func(d Data) int { return d.Int }).
+				ByStringField(func(d Data) string { return d.String }).Descending(),
+			out: []Data{{Int: 0, String: "b"}, {Int: 0, String: "a"}, {Int: 1, String: "b"}, {Int: 1, String: "a"}},
+		},
+		{
+			name: "descending first",
+			s: New[Data]().
+				ByIntField(func(d Data) int { return d.Int }).Descending().
+				ByStringField(func(d Data) string { return d.String }),
+			out: []Data{{Int: 1, String: "a"}, {Int: 1, String: "b"}, {Int: 0, String: "a"}, {Int: 0, String: "b"}},
+		},
+		{
+			name: "ascending overrides",
+			s: New[Data]().
+				ByInt(func(d Data) int { return d.Int }, Desc).
+				ByString(func(d Data) string { return d.String }, Desc).Ascending(),
+			out: []Data{{Int: 1, String: "a"}, {Int: 1, String: "b"}, {Int: 0, String: "a"}, {Int: 0, String: "b"}},
+		},
+		{
+			name: "descending keeps nulls last",
+			s: New[Data]().
+				ByNullableStringFold(nullIfZero, NullsLast, Asc).Descending().
+				ByStringField(func(d Data) string { return d.String }),
+			out: []Data{{Int: 1, String: "b"}, {Int: 1, String: "a"}, {Int: 0, String: "a"}, {Int: 0, String: "b"}},
+		},
+		{
+			name: "ascending keeps nulls first",
+			s: New[Data]().
+				ByNullableStringFold(nullIfZero, NullsFirst, Desc).Ascending().
+				ByStringField(func(d Data) string { return d.String }),
+			out: []Data{{Int: 0, String: "a"}, {Int: 0, String: "b"}, {Int: 1, String: "a"}, {Int: 1, String: "b"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestDescendingEmpty(t *testing.T) {
+	defer func() {
+		if r := recover(); r != errNoProgram {
+			t.Errorf("New().Descending() panicked with %v, want %v", r, errNoProgram)
+		}
+	}()
+	New[Data]().Descending()
+}
+
+func TestCompare(t *testing.T) {
+	s := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Desc).
+		ByFunc(func(l, r Data) bool { return l.String < r.String }, Asc)
+	for _, test := range []struct {
+		l, r Data
+		want int
+	}{
+		{Data{Int: 2}, Data{Int: 1}, -1},
+		{Data{Int: 1}, Data{Int: 2}, 1},
+		{Data{Int: 1, String: "a"}, Data{Int: 1, String: "b"}, -1},
+		{Data{Int: 1, String: "b"}, Data{Int: 1, String: "a"}, 1},
+		{Data{Int: 1, String: "a"}, Data{Int: 1, String: "a"}, 0},
+	} {
+		if got := s.Compare(test.l, test.r); got != test.want {
+			t.Errorf("s.Compare(%v, %v) = %d, want %d", test.l, test.r, got, test.want)
+		}
+		if got := s.AsCompareFunc()(test.l, test.r); got != test.want {
+			t.Errorf("s.AsCompareFunc()(%v, %v) = %d, want %d", test.l, test.r, got, test.want)
+		}
+		if got, want := s.AsLessFunc()(test.l, test.r), test.want < 0; got != want {
+			t.Errorf("s.AsLessFunc()(%v, %v) = %v, want %v", test.l, test.r, got, want)
+		}
+	}
+}
+
+func TestCompareNormalizes(t *testing.T) {
+	s := New[Data]().ByFuncCmp(func(l, r Data) int { return l.Int - r.Int }, Desc)
+	for _, test := range []struct {
+		l, r Data
+		want int
+	}{
+		{Data{Int: 7}, Data{Int: 2}, -1},
+		{Data{Int: 2}, Data{Int: 7}, 1},
+		{Data{Int: 7}, Data{Int: 7}, 0},
+	} {
+		if got := s.Compare(test.l, test.r); got != test.want {
+			t.Errorf("s.Compare(%v, %v) = %d, want %d", test.l, test.r, got, test.want)
+		}
+	}
+}
+
+func TestAdaptersEmpty(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func(s *Sorter[Data])
+	}{
+		{"AsLessFunc", func(s *Sorter[Data]) { s.AsLessFunc() }},
+		{"AsCompareFunc", func(s *Sorter[Data]) { s.AsCompareFunc() }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != errNoProgram {
+					t.Errorf("New().%v() panicked with %v, want %v", test.name, r, errNoProgram)
+				}
+			}()
+			test.f(New[Data]())
+		})
+	}
+}
+
+func TestSetDirections(t *testing.T) {
+	base := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Asc).
+		ByString(func(d Data) string { return d.String }, Asc)
+	in := []Data{
+		{Int: 1, String: "a"},
+		{Int: 0, String: "b"},
+		{Int: 1, String: "b"},
+		{Int: 0, String: "a"},
+	}
+	for _, test := range []struct {
+		dirs []Dir
+		out  []Data
+	}{
+		{[]Dir{Asc, Asc}, []Data{{Int: 0, String: "a"}, {Int: 0, String: "b"}, {Int: 1, String: "a"}, {Int: 1, String: "b"}}},
+		{[]Dir{Desc, Asc}, []Data{{Int: 1, String: "a"}, {Int: 1, String: "b"}, {Int: 0, String: "a"}, {Int: 0, String: "b"}}},
+		{[]Dir{Asc, Desc}, []Data{{Int: 0, String: "b"}, {Int: 0, String: "a"}, {Int: 1, String: "b"}, {Int: 1, String: "a"}}},
+		{[]Dir{Desc, Desc}, []Data{{Int: 1, String: "b"}, {Int: 1, String: "a"}, {Int: 0, String: "b"}, {Int: 0, String: "a"}}},
+	} {
+		t.Run(fmt.Sprint(test.dirs), func(t *testing.T) {
+			s := base.SetDirections(test.dirs)
+			for i, o := range s.prog {
+				if o.Dir != test.dirs[i] {
+					t.Errorf("s.prog[%d].Dir = %v, want %v", i, o.Dir, test.dirs[i])
+				}
+			}
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+	if base.prog[0].Dir != Asc || base.prog[1].Dir != Asc {
+		t.Error("SetDirections modified the base Sorter")
+	}
+}
+
+func TestSetDirectionsMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("SetDirections with one direction for two instructions did not panic")
+		}
+	}()
+	New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Asc).
+		ByString(func(d Data) string { return d.String }, Asc).
+		SetDirections([]Dir{Desc})
+}
+
+func TestSetDirectionsNulls(t *testing.T) {
+	str := func(d Data) *string {
+		if d.String == "" {
+			return nil
+		}
+		return &d.String
+	}
+	in := []Data{
+		{Int: 1, String: "b"},
+		{Int: 0, String: ""},
+		{Int: 2, String: "a"},
+		{Int: 0, String: "c"},
+	}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		out  []Data
+	}{
+		{
+			name: "nulls last desc",
+			s:    New[Data]().ByNullableStringFold(str, NullsLast, Asc).SetDirections([]Dir{Desc}),
+			out:  []Data{{Int: 0, String: "c"}, {Int: 1, String: "b"}, {Int: 2, String: "a"}, {Int: 0, String: ""}},
+		},
+		{
+			name: "nulls first desc",
+			s:    New[Data]().ByNullableStringFold(str, NullsFirst, Asc).SetDirections([]Dir{Desc}),
+			out:  []Data{{Int: 0, String: ""}, {Int: 0, String: "c"}, {Int: 1, String: "b"}, {Int: 2, String: "a"}},
+		},
+		{
+			name: "nulls last asc",
+			s:    New[Data]().ByNullableStringFold(str, NullsLast, Desc).SetDirections([]Dir{Asc}),
+			out:  []Data{{Int: 2, String: "a"}, {Int: 1, String: "b"}, {Int: 0, String: "c"}, {Int: 0, String: ""}},
+		},
+		{
+			name: "non-zero first",
+			s: ByNonZeroFirst(New[Data](), func(d Data) int { return d.Int }, Asc).
+				ByString(func(d Data) string { return d.String }, Asc).
+				SetDirections([]Dir{Desc, Desc}),
+			out: []Data{{Int: 1, String: "b"}, {Int: 2, String: "a"}, {Int: 0, String: "c"}, {Int: 0, String: ""}},
+		},
+		{
+			name: "negated",
+			s:    New[Data]().ByNullableStringFold(str, NullsLast, Asc).NegateIf(0, true),
+			out:  []Data{{Int: 0, String: "c"}, {Int: 1, String: "b"}, {Int: 2, String: "a"}, {Int: 0, String: ""}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, sort := range []struct {
+				name string
+				f    func([]Data)
+			}{
+				{"Less", func(data []Data) { slices.SortFunc(data, test.s.Less) }},
+				{"Compare", func(data []Data) { slices.SortFunc(data, func(l, r Data) bool { return test.s.Compare(l, r) < 0 }) }},
+				{"SortStable", test.s.SortStable},
+			} {
+				out := slices.Clone(in)
+				sort.f(out)
+				if diff := cmp.Diff(test.out, out); diff != "" {
+					t.Errorf("%s: sorting %v = %v, want %v\n\ndiff (-want, +got):\n%v", sort.name, in, out, test.out, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestWithFallback(t *testing.T) {
+	byID := func(l, r Data) int { return compare(l.Uint, r.Uint) }
+	in := []Data{
+		{Int: 1, Uint: 3},
+		{Int: 0, Uint: 2},
+		{Int: 1, Uint: 1},
+		{Int: 0, Uint: 4},
+		{Int: 1, Uint: 2},
+	}
+	base := New[Data]().ByInt(func(d Data) int { return d.Int }, Desc).WithFallback(byID)
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		out  []Data
+	}{
+		{
+			name: "fallback",
+			s:    base,
+			out:  []Data{{Int: 1, Uint: 1}, {Int: 1, Uint: 2}, {Int: 1, Uint: 3}, {Int: 0, Uint: 2}, {Int: 0, Uint: 4}},
+		},
+		{
+			name: "stays last",
+			s:    base.ByBool(func(d Data) bool { return d.Uint%2 == 0 }, Asc),
+			out:  []Data{{Int: 1, Uint: 1}, {Int: 1, Uint: 3}, {Int: 1, Uint: 2}, {Int: 0, Uint: 2}, {Int: 0, Uint: 4}},
+		},
+		{
+			name: "replaced",
+			s:    base.WithFallback(func(l, r Data) int { return compare(r.Uint, l.Uint) }),
+			out:  []Data{{Int: 1, Uint: 3}, {Int: 1, Uint: 2}, {Int: 1, Uint: 1}, {Int: 0, Uint: 4}, {Int: 0, Uint: 2}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, sort := range []struct {
+				name string
+				sort func([]Data)
+			}{
+				{"Less", func(d []Data) { slices.SortFunc(d, test.s.Less) }},
+				{"SortStable", test.s.SortStable},
+			} {
+				out := slices.Clone(in)
+				sort.sort(out)
+				if diff := cmp.Diff(test.out, out); diff != "" {
+					t.Errorf("%v(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", sort.name, in, out, test.out, diff)
+				}
+			}
+		})
+	}
+	if got := base.Compare(Data{Int: 1, Uint: 1}, Data{Int: 1, Uint: 1}); got != 0 {
+		t.Errorf("base.Compare(equal) = %d, want 0", got)
+	}
+	ptrs := []*Data{&in[0], nil, &in[2], nil}
+	slices.SortFunc(ptrs, Pointers(base).Less)
+	if ptrs[0] != nil || ptrs[1] != nil || ptrs[2] != &in[2] || ptrs[3] != &in[0] {
+		t.Errorf("Pointers(base) sorted to %v, want nils then %v, %v", ptrs, in[2], in[0])
+	}
+}
+
 var benchData = []Data{
 	{Int: 0, Uint: 3},
 	{Int: 1, Uint: 1},
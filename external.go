@@ -0,0 +1,219 @@
+//go:build go1.23
+
+package esort
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/exp/slices"
+)
+
+// SortExternal sorts a sequence of data that may not fit in memory according to
+// the Sorter with an external merge sort.  It reads in in chunks of chunkSize
+// elements, sorts each chunk in memory, and spills it to a temporary file using
+// marshal.  The returned sequence merges the files, decoding the elements with
+// unmarshal.  Equal elements keep their original order.
+//
+// in is consumed entirely before SortExternal returns, and any error from
+// marshal or from writing the files is returned then.  If in holds no more
+// than chunkSize elements, no files are written.
+//
+// The returned sequence may be iterated only once and panics otherwise.  The
+// temporary files are removed when its iteration finishes or stops early, so
+// the caller must iterate it to release them.  Errors from reading the files
+// or from unmarshal during the merge cause the iteration to panic, since
+// iter.Seq cannot report them.
+func (s *Sorter[T]) SortExternal(in iter.Seq[T], chunkSize int, marshal func(T) ([]byte, error), unmarshal func([]byte) (T, error)) (iter.Seq[T], error) {
+	if chunkSize < 1 {
+		return nil, fmt.Errorf("esort: chunk size %d is not positive", chunkSize)
+	}
+	var (
+		chunk []T
+		dir   string
+		files []string
+		err   error
+	)
+	spill := func() error {
+		if dir == "" {
+			if dir, err = os.MkdirTemp("", "esort-"); err != nil {
+				return err
+			}
+		}
+		name := filepath.Join(dir, fmt.Sprint(len(files)))
+		files = append(files, name)
+		return writeChunk(name, chunk, marshal)
+	}
+	for v := range in {
+		if len(chunk) == chunkSize {
+			slices.SortStableFunc(chunk, s.Less)
+			if err = spill(); err != nil {
+				break
+			}
+			chunk = chunk[:0]
+		}
+		chunk = append(chunk, v)
+	}
+	if err == nil {
+		slices.SortStableFunc(chunk, s.Less)
+		if files == nil {
+			return func(yield func(T) bool) {
+				for _, v := range chunk {
+					if !yield(v) {
+						return
+					}
+				}
+			}, nil
+		}
+		err = spill()
+	}
+	if err != nil {
+		if dir != "" {
+			os.RemoveAll(dir)
+		}
+		return nil, err
+	}
+	var used bool
+	return func(yield func(T) bool) {
+		if used {
+			panic(errors.New("esort: external sort sequence iterated more than once"))
+		}
+		used = true
+		defer os.RemoveAll(dir)
+		if err := s.merge(files, unmarshal, yield); err != nil {
+			panic(fmt.Errorf("esort: merging external sort chunks: %w", err))
+		}
+	}, nil
+}
+
+// writeChunk writes data to a new file at name as a series of records, each
+// a uvarint length followed by the marshaled element.
+func writeChunk[T any](name string, data []T, marshal func(T) ([]byte, error)) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	var n [binary.MaxVarintLen64]byte
+	for _, v := range data {
+		b, err := marshal(v)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(n[:binary.PutUvarint(n[:], uint64(len(b)))])
+		w.Write(b)
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// chunkReader reads the records of a file written by writeChunk.
+type chunkReader[T any] struct {
+	r         *bufio.Reader
+	unmarshal func([]byte) (T, error)
+	buf       []byte
+}
+
+// next returns the next element in the file.  It returns io.EOF once the file
+// is exhausted.
+func (c *chunkReader[T]) next() (T, error) {
+	var zero T
+	n, err := binary.ReadUvarint(c.r)
+	if err != nil {
+		return zero, err
+	}
+	if uint64(cap(c.buf)) < n {
+		c.buf = make([]byte, n)
+	}
+	c.buf = c.buf[:n]
+	if _, err := io.ReadFull(c.r, c.buf); err != nil {
+		return zero, io.ErrUnexpectedEOF
+	}
+	return c.unmarshal(c.buf)
+}
+
+// mergeHead is the current element of a chunk during the merge.
+type mergeHead[T any] struct {
+	V     T
+	Chunk int
+}
+
+// mergeHeap orders the current elements of the chunks, breaking ties by the
+// chunk order to keep the merge stable.
+type mergeHeap[T any] struct {
+	heads []mergeHead[T]
+	less  func(l, r T) bool
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.heads) }
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	l, r := h.heads[i], h.heads[j]
+	switch {
+	case h.less(l.V, r.V):
+		return true
+	case h.less(r.V, l.V):
+		return false
+	}
+	return l.Chunk < r.Chunk
+}
+func (h *mergeHeap[T]) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+func (h *mergeHeap[T]) Push(x any)    { h.heads = append(h.heads, x.(mergeHead[T])) }
+func (h *mergeHeap[T]) Pop() any {
+	v := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return v
+}
+
+// merge yields the elements of the sorted chunk files in order until the
+// files are exhausted or yield returns false.
+func (s *Sorter[T]) merge(files []string, unmarshal func([]byte) (T, error), yield func(T) bool) error {
+	readers := make([]*chunkReader[T], len(files))
+	for i, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		readers[i] = &chunkReader[T]{r: bufio.NewReader(f), unmarshal: unmarshal}
+	}
+	h := &mergeHeap[T]{less: s.Less}
+	for i, r := range readers {
+		v, err := r.next()
+		switch {
+		case err == io.EOF:
+			continue
+		case err != nil:
+			return err
+		}
+		h.heads = append(h.heads, mergeHead[T]{v, i})
+	}
+	heap.Init(h)
+	for h.Len() > 0 {
+		head := h.heads[0]
+		if !yield(head.V) {
+			return nil
+		}
+		v, err := readers[head.Chunk].next()
+		switch {
+		case err == io.EOF:
+			heap.Pop(h)
+			continue
+		case err != nil:
+			return err
+		}
+		h.heads[0].V = v
+		heap.Fix(h, 0)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+//go:build go1.23
+
+package esort
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSortExternal(t *testing.T) {
+	marshal := func(d Data) ([]byte, error) { return json.Marshal(d) }
+	unmarshal := func(b []byte) (Data, error) {
+		var d Data
+		err := json.Unmarshal(b, &d)
+		return d, err
+	}
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Desc)
+	for _, test := range []struct {
+		name      string
+		n         int
+		chunkSize int
+	}{
+		{"empty", 0, 10},
+		{"single chunk", 10, 10},
+		{"partial last chunk", 95, 10},
+		{"full last chunk", 100, 10},
+		{"one per chunk", 20, 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			t.Setenv("TMPDIR", tmp)
+			in := make([]Data, test.n)
+			for i := range in {
+				in[i] = Data{Int: i * 7 % 13, Uint: uint(i)}
+			}
+			want := slices.Clone(in)
+			slices.SortStableFunc(want, func(l, r Data) int { return compare(r.Int, l.Int) })
+
+			seq, err := s.SortExternal(slices.Values(in), test.chunkSize, marshal, unmarshal)
+			if err != nil {
+				t.Fatalf("s.SortExternal(…, %d, …) = %v", test.chunkSize, err)
+			}
+			got := slices.Collect(seq)
+			if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("s.SortExternal(%v, %d, …) = %v, want %v\n\ndiff (-want, +got):\n%v", in, test.chunkSize, got, want, diff)
+			}
+			entries, err := os.ReadDir(tmp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 0 {
+				t.Errorf("temporary directory holds %v after iteration, want nothing", entries)
+			}
+		})
+	}
+}
+
+func TestSortExternalStop(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	s := New[int]().ByInt(func(v int) int { return v }, Asc)
+	marshal := func(v int) ([]byte, error) { return []byte{byte(v)}, nil }
+	unmarshal := func(b []byte) (int, error) { return int(b[0]), nil }
+	seq, err := s.SortExternal(slices.Values([]int{5, 3, 9, 1, 7, 2}), 2, marshal, unmarshal)
+	if err != nil {
+		t.Fatalf("s.SortExternal(…) = %v", err)
+	}
+	var got []int
+	for v := range seq {
+		if got = append(got, v); len(got) == 3 {
+			break
+		}
+	}
+	if diff := cmp.Diff([]int{1, 2, 3}, got); diff != "" {
+		t.Errorf("first three elements = %v, want [1 2 3]\n\ndiff (-want, +got):\n%v", got, diff)
+	}
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("temporary directory holds %v after stopping, want nothing", entries)
+	}
+}
+
+func TestSortExternalMarshalError(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	errBad := errors.New("bad element")
+	s := New[int]().ByInt(func(v int) int { return v }, Asc)
+	marshal := func(v int) ([]byte, error) {
+		if v == 4 {
+			return nil, errBad
+		}
+		return []byte{byte(v)}, nil
+	}
+	unmarshal := func(b []byte) (int, error) { return int(b[0]), nil }
+	if _, err := s.SortExternal(slices.Values([]int{1, 2, 3, 4, 5}), 2, marshal, unmarshal); !errors.Is(err, errBad) {
+		t.Errorf("s.SortExternal(…) = %v, want %v", err, errBad)
+	}
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("temporary directory holds %v after failure, want nothing", entries)
+	}
+}
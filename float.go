@@ -0,0 +1,65 @@
+package esort
+
+import "math"
+
+// totalOrderKey maps f to an unsigned integer whose natural ordering matches
+// the IEEE 754 totalOrder predicate: negative values have all bits inverted so
+// that larger magnitudes sort first, and non-negative values have their sign
+// bit set so that they sort after all negative values.
+func totalOrderKey(f float64) uint64 {
+	b := math.Float64bits(f)
+	if b>>63 == 1 {
+		return ^b
+	}
+	return b | 1<<63
+}
+
+// ByFloat64TotalOrder sorts the data by a given float64 value according to
+// the IEEE 754 totalOrder predicate.  Unlike [Sorter.ByFloat64], the ordering
+// is total: negative NaNs sort before -Inf, -0.0 sorts before +0.0, and
+// positive NaNs sort after +Inf.  NaNs are further ordered by their payloads.
+func (s *Sorter[T]) ByFloat64TotalOrder(f func(T) float64, d Dir) *Sorter[T] {
+	key := func(v T) uint64 { return totalOrderKey(f(v)) }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d, Kind: KindFloat})
+}
+
+// ByComplex128RealImag sorts the data by a given complex128 value in
+// dictionary order in the complex plane: by the real part and then by the
+// imaginary part.  Both parts are compared according to the IEEE 754
+// totalOrder predicate as in [Sorter.ByFloat64TotalOrder], so NaN parts are
+// ordered consistently rather than tying with everything, and -0.0 sorts
+// before +0.0.
+func (s *Sorter[T]) ByComplex128RealImag(f func(T) complex128, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		lv, rv := f(l), f(r)
+		for _, p := range [2][2]float64{{real(lv), real(rv)}, {imag(lv), imag(rv)}} {
+			switch lk, rk := totalOrderKey(p[0]), totalOrderKey(p[1]); {
+			case lk < rk:
+				return -1
+			case rk < lk:
+				return 1
+			}
+		}
+		return 0
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindFloat})
+}
+
+// ByFloat64Money sorts the data by a given float64 value, such as a monetary
+// amount, such that -0.0 and +0.0 tie, as they do under ==, rather than being
+// told apart as in [Sorter.ByFloat64TotalOrder].  Unlike [Sorter.ByFloat64],
+// the ordering is total: NaNs of any sign or payload tie with one another and
+// sort after +Inf in ascending order and thus first in descending order.
+func (s *Sorter[T]) ByFloat64Money(f func(T) float64, d Dir) *Sorter[T] {
+	key := func(v T) uint64 {
+		switch x := f(v); {
+		case x == 0:
+			return totalOrderKey(0)
+		case x != x:
+			return totalOrderKey(math.NaN())
+		default:
+			return totalOrderKey(x)
+		}
+	}
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d, Kind: KindFloat})
+}
@@ -0,0 +1,140 @@
+package esort
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+// bits renders the data's Float64 fields as bit patterns, since NaNs never
+// compare equal.
+func bits(data []Data) []uint64 {
+	out := make([]uint64, len(data))
+	for i, d := range data {
+		out[i] = math.Float64bits(d.Float64)
+	}
+	return out
+}
+
+func TestByFloat64TotalOrder(t *testing.T) {
+	var (
+		negNaN  = math.Float64frombits(0xfff8000000000000)
+		posNaN  = math.Float64frombits(0x7ff8000000000000)
+		negZero = math.Copysign(0, -1)
+	)
+	asc := []Data{
+		{Float64: negNaN},
+		{Float64: math.Inf(-1)},
+		{Float64: -1},
+		{Float64: negZero},
+		{Float64: 0},
+		{Float64: 1},
+		{Float64: math.Inf(1)},
+		{Float64: posNaN},
+	}
+	desc := make([]Data, len(asc))
+	copy(desc, asc)
+	reverse(desc)
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []Data
+	}{
+		{"asc", Asc, asc},
+		{"desc", Desc, desc},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[Data]().ByFloat64TotalOrder(func(d Data) float64 { return d.Float64 }, test.d)
+			for _, in := range [][]Data{asc, desc} {
+				out := make([]Data, len(in))
+				copy(out, in)
+				slices.SortFunc(out, s.Less)
+				if diff := cmp.Diff(bits(test.out), bits(out)); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestByComplex128RealImag(t *testing.T) {
+	nan := math.NaN()
+	asc := []complex128{
+		complex(-1, 5),
+		complex(0, -2),
+		complex(0, 0),
+		complex(0, 3),
+		complex(0, nan),
+		complex(2, -10),
+		complex(2, 1),
+		complex(nan, -1),
+		complex(nan, 1),
+	}
+	desc := slices.Clone(asc)
+	reverse(desc)
+	// bits renders the values as bit patterns, since NaNs never compare equal.
+	bits := func(vs []complex128) [][2]uint64 {
+		out := make([][2]uint64, len(vs))
+		for i, v := range vs {
+			out[i] = [2]uint64{math.Float64bits(real(v)), math.Float64bits(imag(v))}
+		}
+		return out
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []complex128
+	}{
+		{"asc", Asc, asc},
+		{"desc", Desc, desc},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[complex128]().ByComplex128RealImag(func(v complex128) complex128 { return v }, test.d)
+			for _, in := range [][]complex128{asc, desc} {
+				out := slices.Clone(in)
+				slices.SortFunc(out, s.Less)
+				if diff := cmp.Diff(bits(test.out), bits(out)); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestByFloat64Money(t *testing.T) {
+	negNaN := math.Float64frombits(0xfff8000000000000)
+	in := []Data{
+		{Int: 1, Float64: 0},
+		{Int: 2, Float64: math.Copysign(0, -1)},
+		{Int: 3, Float64: negNaN},
+		{Int: 4, Float64: -5},
+		{Int: 5, Float64: math.NaN()},
+		{Int: 6, Float64: math.Inf(1)},
+		{Int: 7, Float64: 0},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []int
+	}{
+		{"asc", Asc, []int{4, 1, 2, 7, 6, 3, 5}},
+		{"desc", Desc, []int{3, 5, 6, 1, 2, 7, 4}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[Data]().
+				ByFloat64Money(func(d Data) float64 { return d.Float64 }, test.d).
+				ByInt(func(d Data) int { return d.Int }, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []int
+			for _, d := range out {
+				got = append(got, d.Int)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(…) = %v, want %v\n\ndiff (-want, +got):\n%v", got, test.out, diff)
+			}
+		})
+	}
+}
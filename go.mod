@@ -5,4 +5,6 @@ go 1.19
 require (
 	github.com/google/go-cmp v0.5.9
 	golang.org/x/exp v0.0.0-20230125214544-b3c2aaf6208d
+	golang.org/x/text v0.14.0
+	google.golang.org/protobuf v1.31.0
 )
@@ -0,0 +1,43 @@
+package esort
+
+import "bytes"
+
+// fnv1a returns the 64-bit FNV-1a hash of b.
+func fnv1a(b []byte) uint64 {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+	h := uint64(offset)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= prime
+	}
+	return h
+}
+
+// ByContentHash sorts the data by a fixed hash of a given byte slice value,
+// which orders elements pseudo-randomly yet deterministically: the same data
+// always sorts the same way, across runs and processes, without the order
+// being biased by the values themselves.  It suits a final instruction that
+// breaks ties reproducibly, such as in tests:
+//
+//	sorter := esort.New[Person]().
+//		ByInt(func(p Person) int { return p.Age }, esort.Desc).
+//		ByContentHash(func(p Person) []byte { return []byte(p.GivenName) }, esort.Asc)
+//
+// Values whose hashes collide are ordered by [bytes.Compare], so only equal
+// values tie.  The hash is FNV-1a and is computed twice per comparison.
+func (s *Sorter[T]) ByContentHash(f func(T) []byte, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		lv, rv := f(l), f(r)
+		switch lh, rh := fnv1a(lv), fnv1a(rv); {
+		case lh < rh:
+			return -1
+		case rh < lh:
+			return 1
+		}
+		return bytes.Compare(lv, rv)
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d})
+}
@@ -0,0 +1,66 @@
+package esort
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestFNV1a(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want uint64
+	}{
+		{"", 0xcbf29ce484222325},
+		{"a", 0xaf63dc4c8601ec8c},
+		{"foobar", 0x85944171f73967e8},
+	} {
+		if got := fnv1a([]byte(test.in)); got != test.want {
+			t.Errorf("fnv1a(%q) = %#x, want %#x", test.in, got, test.want)
+		}
+	}
+}
+
+func TestByContentHash(t *testing.T) {
+	in := make([]Data, 100)
+	for i := range in {
+		in[i] = Data{Int: i % 2, String: fmt.Sprint(i)}
+	}
+	s := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Asc).
+		ByContentHash(func(d Data) []byte { return []byte(d.String) }, Asc)
+
+	first := slices.Clone(in)
+	slices.SortFunc(first, s.Less)
+	second := slices.Clone(in)
+	reverse(second)
+	slices.SortFunc(second, s.Less)
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("orders differ between runs\n\ndiff (-first, +second):\n%v", diff)
+	}
+
+	for i, d := range first {
+		if got, want := d.Int, i/50; got != want {
+			t.Fatalf("first[%d].Int = %d, want %d", i, got, want)
+		}
+	}
+	// Within each group of ties, count how many of the lower half of the
+	// group by input order landed in the lower half of the group by output
+	// order.  An unbiased order mixes the halves.
+	for g := 0; g < 2; g++ {
+		group := first[g*50 : (g+1)*50]
+		var low int
+		for _, d := range group[:25] {
+			var n int
+			fmt.Sscan(d.String, &n)
+			if n < 50 {
+				low++
+			}
+		}
+		if low < 5 || low > 20 {
+			t.Errorf("group %d has %d of 25 elements from the lower half of the input in its lower half, want between 5 and 20", g, low)
+		}
+	}
+}
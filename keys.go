@@ -0,0 +1,287 @@
+package esort
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// ByPriorityList sorts the data by the position of a given key in order, so
+// that keys appearing earlier in order sort first in ascending order.  Keys
+// that do not appear in order are placed according to unknown and tie with one
+// another.  If a key appears in order more than once, its first position is
+// used.
+//
+// The positions are indexed once when the instruction is created, so order
+// may be large and later modifications to it have no effect on the Sorter.
+func ByPriorityList[T any, K comparable](s *Sorter[T], f func(T) K, order []K, unknown NullPlacement, d Dir) *Sorter[T] {
+	rank := make(map[K]int, len(order))
+	for i, k := range order {
+		if _, ok := rank[k]; !ok {
+			rank[k] = i
+		}
+	}
+	fn := func(l, r T, d Dir) int {
+		lp, lok := rank[f(l)]
+		rp, rok := rank[f(r)]
+		if less, ok := nullLess(!lok, !rok, unknown, d); ok {
+			switch {
+			case less:
+				return -1
+			case lok == rok:
+				return 0
+			}
+			return 1
+		}
+		return compareInt(lp, rp)
+	}
+	return s.addInst(inst[T]{CmpDir: fn, Dir: d})
+}
+
+// ByScore sorts the data by a given score, such as the relevance of a search
+// result.  It is equivalent to [Sorter.ByFloat64] but names the intent at the
+// call site.
+//
+// The score is computed twice per comparison.  When scoring is expensive and
+// the score is the only sorting criterion, prefer [SortByScore], which computes
+// it once per element.
+func ByScore[T any](s *Sorter[T], score func(T) float64, d Dir) *Sorter[T] {
+	return s.ByFloat64(score, d)
+}
+
+// ByKey sorts the data by an ordered key computed from each element by f.  It
+// is the generic form of the typed By methods and accepts defined types whose
+// underlying type is ordered without conversion:
+//
+//	type Score float64
+//
+//	sorter := esort.ByKey(esort.New[Player](), func(p Player) Score { return p.Score }, esort.Desc)
+//
+// The key is computed twice per comparison; when it is expensive and is the
+// only sorting criterion, prefer [SortByKey], which computes it once per
+// element.
+func ByKey[T any, K constraints.Ordered](s *Sorter[T], f func(T) K, d Dir) *Sorter[T] {
+	return s.addInst(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: kindOf[K]()})
+}
+
+// ByFuncKey sorts the data by an ordered key computed from each element by
+// key.  It is equivalent to [ByKey].
+func ByFuncKey[T any, K constraints.Ordered](s *Sorter[T], key func(T) K, d Dir) *Sorter[T] {
+	return ByKey(s, key, d)
+}
+
+// ByNonZeroFirst sorts the data by whether a given value is the zero value of
+// its type, placing elements with non-zero values before those with zero
+// values regardless of d, which is accepted for symmetry with the other By
+// functions, and of any direction set later, such as with
+// [Sorter.SetDirections].  Later instructions then order the elements within
+// each group, and their directions do not affect the placement of the zero
+// values either:
+//
+//	sorter := esort.ByNonZeroFirst(esort.New[Person](), func(p Person) string { return p.Nickname }, esort.Asc).
+//		ByString(func(p Person) string { return p.Nickname }, esort.Desc)
+//
+// The instruction has kind [KindOther], so [Sorter.ReverseKind] with
+// [KindBool] leaves it alone.
+func ByNonZeroFirst[T any, V comparable](s *Sorter[T], f func(T) V, d Dir) *Sorter[T] {
+	var zero V
+	fn := func(l, r T, d Dir) int {
+		lz, rz := f(l) == zero, f(r) == zero
+		if less, ok := nullLess(lz, rz, NullsLast, d); ok && lz != rz {
+			if less {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	}
+	return s.addInst(inst[T]{CmpDir: fn, Dir: Asc})
+}
+
+// ByLargest sorts the data by a given numeric value with the largest values
+// first.  It is equivalent to the typed By method for the value's type with
+// [Desc].
+func ByLargest[T any, V constraints.Integer | constraints.Float](s *Sorter[T], f func(T) V) *Sorter[T] {
+	return s.addInst(inst[T]{Cmp: compareFunc(f), Dir: Desc, Kind: kindOf[V]()})
+}
+
+// BySmallest sorts the data by a given numeric value with the smallest values
+// first.  It is equivalent to the typed By method for the value's type with
+// [Asc].
+func BySmallest[T any, V constraints.Integer | constraints.Float](s *Sorter[T], f func(T) V) *Sorter[T] {
+	return s.addInst(inst[T]{Cmp: compareFunc(f), Dir: Asc, Kind: kindOf[V]()})
+}
+
+// ByAlphabetical sorts the data by a given string value in ascending order.
+// It is equivalent to [Sorter.ByString] with [Asc].
+func (s *Sorter[T]) ByAlphabetical(f func(T) string) *Sorter[T] {
+	return s.ByString(f, Asc)
+}
+
+// ByReverseAlphabetical sorts the data by a given string value in descending
+// order.  It is equivalent to [Sorter.ByString] with [Desc].
+func (s *Sorter[T]) ByReverseAlphabetical(f func(T) string) *Sorter[T] {
+	return s.ByString(f, Desc)
+}
+
+// bandIndex returns the number of bounds that are less than or equal to v,
+// which is the index of the band containing v.
+func bandIndex[V constraints.Ordered](bounds []V, v V) int {
+	return sort.Search(len(bounds), func(i int) bool { return bounds[i] > v })
+}
+
+// ByBand sorts the data by the band that a given score falls into, so that
+// elements with noisy scores in the same band tie and later instructions can
+// order them.  bounds lists the edges between the bands in ascending order: a
+// score below bounds[0] falls into band 0, a score in [bounds[i-1], bounds[i])
+// into band i, and a score at or above the last bound into band len(bounds).
+// For instance, to sort by score deciles and then by recency:
+//
+//	deciles := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+//	sorter := esort.New[Result]().
+//		ByBand(func(r Result) float64 { return r.Score }, deciles, esort.Desc).
+//		ByInt64(func(r Result) int64 { return r.Published.Unix() }, esort.Desc)
+//
+// ByBand panics if bounds is not sorted in ascending order.  bounds is copied,
+// so later modifications to it have no effect on the Sorter.  Each comparison
+// performs a binary search over bounds.  NaN scores fall into the last band.
+func (s *Sorter[T]) ByBand(score func(T) float64, bounds []float64, d Dir) *Sorter[T] {
+	if !sort.Float64sAreSorted(bounds) {
+		panic(fmt.Errorf("esort: bounds %v are not sorted in ascending order", bounds))
+	}
+	bounds = slices.Clone(bounds)
+	key := func(v T) int { return bandIndex(bounds, score(v)) }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d})
+}
+
+// ByBoolFlags sorts the data by several boolean flags with a priority among
+// them, such as whether an item is pinned, then featured, then verified.  The
+// flags are packed into an integer with the first flag as its most significant
+// bit, so a true higher-priority flag outweighs any combination of lower ones.
+// In ascending order elements with false flags sort first, so sort in
+// descending order to place flagged elements first:
+//
+//	sorter := esort.New[Item]().
+//		ByBoolFlags([]func(Item) bool{Item.Pinned, Item.Featured, Item.Verified}, esort.Desc)
+//
+// It is equivalent to chaining [Sorter.ByBool] for each flag in order with the
+// same direction.  ByBoolFlags panics if given more than 64 flags.  flags is
+// copied, so later modifications to it have no effect on the Sorter.
+func (s *Sorter[T]) ByBoolFlags(flags []func(T) bool, d Dir) *Sorter[T] {
+	if len(flags) > 64 {
+		panic(fmt.Errorf("esort: %d flags exceed the maximum of 64", len(flags)))
+	}
+	flags = slices.Clone(flags)
+	key := func(v T) uint64 {
+		var k uint64
+		for _, f := range flags {
+			k <<= 1
+			if f(v) {
+				k |= 1
+			}
+		}
+		return k
+	}
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d})
+}
+
+// ByOrdinal sorts the data by a given enumerated value in the order of its
+// underlying integer, which for enumerations declared with iota is their
+// declaration order.  It accepts any integer-based enumeration type without
+// conversion:
+//
+//	type Status int
+//
+//	const (
+//		Pending Status = iota
+//		Running
+//		Done
+//	)
+//
+//	sorter := esort.ByOrdinal(esort.New[Job](), func(j Job) Status { return j.Status }, esort.Asc)
+func ByOrdinal[T any, E constraints.Integer](s *Sorter[T], f func(T) E, d Dir) *Sorter[T] {
+	return s.addInst(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByTopoRank sorts the data by the rank of a given key in the partial order
+// described by edges, where each edge {a, b} requires a to sort before b in
+// ascending order, such as a precedence among enumeration values that is not a
+// simple list.  A key's rank is the length of the longest chain of edges
+// leading to it, so keys without predecessors have rank 0, and each key ranks
+// above all of its predecessors.  Keys of equal rank tie, as do keys that
+// appear in no edge, which have rank 0.
+//
+//	sorter := esort.ByTopoRank(esort.New[Task](), func(t Task) Phase { return t.Phase }, [][2]Phase{
+//		{Plan, Build}, {Plan, Design}, {Design, Build}, {Build, Ship},
+//	}, esort.Asc)
+//
+// The ranks are computed once when the instruction is created.  ByTopoRank
+// panics with an error if edges contain a cycle, since no order can then
+// satisfy them.  The error lists the keys that lie on a cycle, leaving out
+// those that merely follow one.
+func ByTopoRank[T any, K comparable](s *Sorter[T], f func(T) K, edges [][2]K, d Dir) *Sorter[T] {
+	var nodes []K
+	succ := make(map[K][]K)
+	indeg := make(map[K]int)
+	for _, e := range edges {
+		for _, k := range e {
+			if _, ok := indeg[k]; !ok {
+				indeg[k] = 0
+				nodes = append(nodes, k)
+			}
+		}
+		succ[e[0]] = append(succ[e[0]], e[1])
+		indeg[e[1]]++
+	}
+	rank := make(map[K]int, len(nodes))
+	var queue []K
+	for _, k := range nodes {
+		if indeg[k] == 0 {
+			queue = append(queue, k)
+		}
+	}
+	for done := 0; done < len(queue); done++ {
+		k := queue[done]
+		for _, n := range succ[k] {
+			if r := rank[k] + 1; r > rank[n] {
+				rank[n] = r
+			}
+			if indeg[n]--; indeg[n] == 0 {
+				queue = append(queue, n)
+			}
+		}
+	}
+	if len(queue) < len(nodes) {
+		var cyclic []K
+		for _, k := range nodes {
+			if indeg[k] > 0 && reaches(succ, k, k) {
+				cyclic = append(cyclic, k)
+			}
+		}
+		panic(fmt.Errorf("esort: edges contain a cycle through %v", cyclic))
+	}
+	key := func(v T) int { return rank[f(v)] }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d})
+}
+
+// reaches reports whether to can be reached from from by following at least
+// one edge of succ.
+func reaches[K comparable](succ map[K][]K, from, to K) bool {
+	seen := make(map[K]bool)
+	stack := append([]K(nil), succ[from]...)
+	for len(stack) > 0 {
+		k := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if k == to {
+			return true
+		}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		stack = append(stack, succ[k]...)
+	}
+	return false
+}
@@ -0,0 +1,415 @@
+package esort
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestByPriorityList(t *testing.T) {
+	// order is a permutation of [0, 1000) that starts 0, 7, 14, 21, ….
+	order := make([]int, 1000)
+	for i := range order {
+		order[i] = i * 7 % 1000
+	}
+	key := func(d Data) int { return d.Int }
+	in := []Data{{Int: 1}, {Int: -1}, {Int: 14}, {Int: 1000}, {Int: 7}, {Int: 0}}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		out  []Data
+	}{
+		{
+			name: "unknown last asc",
+			s:    ByPriorityList(New[Data](), key, order, NullsLast, Asc),
+			out:  []Data{{Int: 0}, {Int: 7}, {Int: 14}, {Int: 1}, {Int: -1}, {Int: 1000}},
+		},
+		{
+			name: "unknown first asc",
+			s:    ByPriorityList(New[Data](), key, order, NullsFirst, Asc),
+			out:  []Data{{Int: -1}, {Int: 1000}, {Int: 0}, {Int: 7}, {Int: 14}, {Int: 1}},
+		},
+		{
+			name: "unknown last desc",
+			s:    ByPriorityList(New[Data](), key, order, NullsLast, Desc),
+			out:  []Data{{Int: 1}, {Int: 14}, {Int: 7}, {Int: 0}, {Int: -1}, {Int: 1000}},
+		},
+		{
+			name: "unknown first desc",
+			s:    ByPriorityList(New[Data](), key, order, NullsFirst, Desc),
+			out:  []Data{{Int: -1}, {Int: 1000}, {Int: 1}, {Int: 14}, {Int: 7}, {Int: 0}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := make([]Data, len(in))
+			copy(out, in)
+			slices.SortStableFunc(out, test.s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortStableFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByNonZeroFirst(t *testing.T) {
+	str := func(d Data) string { return d.String }
+	num := func(d Data) int { return d.Int }
+	in := []Data{
+		{String: "", Int: 2},
+		{String: "b", Int: 0},
+		{String: "a", Int: 3},
+		{String: "", Int: 1},
+		{String: "c", Int: 0},
+	}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		out  []Data
+	}{
+		{
+			name: "string asc then desc",
+			s:    ByNonZeroFirst(New[Data](), str, Asc).ByString(str, Desc).ByInt(num, Asc),
+			out: []Data{
+				{String: "c", Int: 0},
+				{String: "b", Int: 0},
+				{String: "a", Int: 3},
+				{String: "", Int: 1},
+				{String: "", Int: 2},
+			},
+		},
+		{
+			name: "string desc",
+			s:    ByNonZeroFirst(New[Data](), str, Desc).ByString(str, Asc).ByInt(num, Asc),
+			out: []Data{
+				{String: "a", Int: 3},
+				{String: "b", Int: 0},
+				{String: "c", Int: 0},
+				{String: "", Int: 1},
+				{String: "", Int: 2},
+			},
+		},
+		{
+			name: "int desc then desc",
+			s:    ByNonZeroFirst(New[Data](), num, Desc).ByInt(num, Desc).ByString(str, Asc),
+			out: []Data{
+				{String: "a", Int: 3},
+				{String: "", Int: 2},
+				{String: "", Int: 1},
+				{String: "b", Int: 0},
+				{String: "c", Int: 0},
+			},
+		},
+		{
+			name: "int asc",
+			s:    ByNonZeroFirst(New[Data](), num, Asc).ByInt(num, Asc).ByString(str, Asc),
+			out: []Data{
+				{String: "", Int: 1},
+				{String: "", Int: 2},
+				{String: "a", Int: 3},
+				{String: "b", Int: 0},
+				{String: "c", Int: 0},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestNamedDirections(t *testing.T) {
+	str := func(d Data) string { return d.String }
+	num := func(d Data) int { return d.Int }
+	flt := func(d Data) float64 { return float64(d.Int) / 2 }
+	in := []Data{
+		{String: "b", Int: 2},
+		{String: "a", Int: 3},
+		{String: "c", Int: 2},
+		{String: "a", Int: 1},
+		{String: "b", Int: 1},
+	}
+	for _, test := range []struct {
+		name       string
+		named, dir *Sorter[Data]
+	}{
+		{
+			name:  "largest then alphabetical",
+			named: ByLargest(New[Data](), num).ByAlphabetical(str),
+			dir:   New[Data]().ByInt(num, Desc).ByString(str, Asc),
+		},
+		{
+			name:  "smallest then reverse alphabetical",
+			named: BySmallest(New[Data](), num).ByReverseAlphabetical(str),
+			dir:   New[Data]().ByInt(num, Asc).ByString(str, Desc),
+		},
+		{
+			name:  "alphabetical then largest float",
+			named: ByLargest(New[Data]().ByAlphabetical(str), flt),
+			dir:   New[Data]().ByString(str, Asc).ByFloat64(flt, Desc),
+		},
+		{
+			name:  "reverse alphabetical then smallest float",
+			named: BySmallest(New[Data]().ByReverseAlphabetical(str), flt),
+			dir:   New[Data]().ByString(str, Desc).ByFloat64(flt, Asc),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			want := slices.Clone(in)
+			slices.SortFunc(want, test.dir.Less)
+			got := slices.Clone(in)
+			slices.SortFunc(got, test.named.Less)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, want, diff)
+			}
+		})
+	}
+}
+
+func TestBandIndex(t *testing.T) {
+	bounds := []float64{0.25, 0.5, 0.75}
+	for _, test := range []struct {
+		v    float64
+		want int
+	}{
+		{-1, 0},
+		{0, 0},
+		{0.24, 0},
+		{0.25, 1},
+		{0.3, 1},
+		{0.5, 2},
+		{0.74, 2},
+		{0.75, 3},
+		{1, 3},
+		{math.NaN(), 3},
+	} {
+		if got := bandIndex(bounds, test.v); got != test.want {
+			t.Errorf("bandIndex(%v, %v) = %d, want %d", bounds, test.v, got, test.want)
+		}
+	}
+}
+
+func TestByBand(t *testing.T) {
+	bounds := []float64{0.5}
+	score := func(d Data) float64 { return d.Float64 }
+	num := func(d Data) int { return d.Int }
+	s := New[Data]().ByBand(score, bounds, Desc).ByInt(num, Desc)
+	bounds[0] = 0 // Must not affect the Sorter.
+	in := []Data{
+		{Float64: 0.1, Int: 4},
+		{Float64: 0.9, Int: 1},
+		{Float64: 0.5, Int: 2},
+		{Float64: 0.49, Int: 5},
+		{Float64: 0.6, Int: 3},
+	}
+	want := []Data{
+		{Float64: 0.6, Int: 3},
+		{Float64: 0.5, Int: 2},
+		{Float64: 0.9, Int: 1},
+		{Float64: 0.49, Int: 5},
+		{Float64: 0.1, Int: 4},
+	}
+	got := slices.Clone(in)
+	slices.SortFunc(got, s.Less)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, want, diff)
+	}
+}
+
+func TestByBandUnsorted(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("New[Data]().ByBand(…, []float64{2, 1}, Asc) did not panic")
+		}
+	}()
+	New[Data]().ByBand(func(d Data) float64 { return d.Float64 }, []float64{2, 1}, Asc)
+}
+
+func TestByKey(t *testing.T) {
+	type Score float64
+	type player struct {
+		Name  string
+		Score Score
+	}
+	score := func(p player) Score { return p.Score }
+	name := func(p player) string { return p.Name }
+	in := []player{{"a", 1.5}, {"b", -2}, {"c", 10}, {"d", 1.5}}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []player
+	}{
+		{"asc", Asc, []player{{"b", -2}, {"a", 1.5}, {"d", 1.5}, {"c", 10}}},
+		{"desc", Desc, []player{{"c", 10}, {"a", 1.5}, {"d", 1.5}, {"b", -2}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByKey(New[player](), score, test.d).ByString(name, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByBoolFlags(t *testing.T) {
+	type item struct {
+		Name                       string
+		Pinned, Featured, Verified bool
+	}
+	flags := []func(item) bool{
+		func(i item) bool { return i.Pinned },
+		func(i item) bool { return i.Featured },
+		func(i item) bool { return i.Verified },
+	}
+	name := func(i item) string { return i.Name }
+	in := []item{
+		{Name: "verified", Verified: true},
+		{Name: "none"},
+		{Name: "pinned", Pinned: true},
+		{Name: "featured verified", Featured: true, Verified: true},
+		{Name: "pinned verified", Pinned: true, Verified: true},
+		{Name: "featured", Featured: true},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"none", "verified", "featured", "featured verified", "pinned", "pinned verified"}},
+		{"desc", Desc, []string{"pinned verified", "pinned", "featured verified", "featured", "verified", "none"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[item]().ByBoolFlags(flags, test.d).ByString(name, Asc)
+			chained := New[item]().ByBool(flags[0], test.d).ByBool(flags[1], test.d).ByBool(flags[2], test.d).ByString(name, Asc)
+			for _, s := range []*Sorter[item]{s, chained} {
+				out := slices.Clone(in)
+				slices.SortFunc(out, s.Less)
+				var got []string
+				for _, i := range out {
+					got = append(got, i.Name)
+				}
+				if diff := cmp.Diff(test.out, got); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+				}
+			}
+		})
+	}
+}
+
+type status int
+
+const (
+	statusPending status = iota
+	statusRunning
+	statusDone
+)
+
+func (s status) String() string {
+	return [...]string{"pending", "running", "done"}[s]
+}
+
+func TestByOrdinal(t *testing.T) {
+	type job struct {
+		Name   string
+		Status status
+	}
+	st := func(j job) status { return j.Status }
+	name := func(j job) string { return j.Name }
+	in := []job{
+		{"a", statusDone},
+		{"b", statusPending},
+		{"c", statusRunning},
+		{"d", statusPending},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []job
+	}{
+		{"asc", Asc, []job{{"b", statusPending}, {"d", statusPending}, {"c", statusRunning}, {"a", statusDone}}},
+		{"desc", Desc, []job{{"a", statusDone}, {"c", statusRunning}, {"b", statusPending}, {"d", statusPending}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByOrdinal(New[job](), st, test.d).ByString(name, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByTopoRank(t *testing.T) {
+	id := func(s string) string { return s }
+	edges := [][2]string{
+		{"plan", "build"},
+		{"plan", "design"},
+		{"design", "build"},
+		{"build", "ship"},
+		{"plan", "docs"},
+	}
+	in := []string{"ship", "other", "build", "docs", "plan", "design"}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"other", "plan", "design", "docs", "build", "ship"}},
+		{"desc", Desc, []string{"ship", "build", "design", "docs", "other", "plan"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByTopoRank(New[string](), id, edges, test.d).ByString(id, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByTopoRankCycle(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		edges [][2]string
+		want  string
+	}{
+		{
+			name:  "cycle",
+			edges: [][2]string{{"start", "rock"}, {"rock", "paper"}, {"paper", "scissors"}, {"scissors", "rock"}},
+			want:  "esort: edges contain a cycle through [rock paper scissors]",
+		},
+		{
+			name:  "downstream of cycle",
+			edges: [][2]string{{"rock", "paper"}, {"paper", "rock"}, {"paper", "lizard"}, {"lizard", "spock"}},
+			want:  "esort: edges contain a cycle through [rock paper]",
+		},
+		{
+			name:  "between cycles",
+			edges: [][2]string{{"a", "b"}, {"b", "a"}, {"b", "c"}, {"c", "d"}, {"d", "d"}},
+			want:  "esort: edges contain a cycle through [a b d]",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatal("ByTopoRank with a cycle did not panic")
+				}
+				if got := fmt.Sprint(r); got != test.want {
+					t.Errorf("ByTopoRank panicked with %q, want %q", got, test.want)
+				}
+			}()
+			ByTopoRank(New[string](), func(s string) string { return s }, test.edges, Asc)
+		})
+	}
+}
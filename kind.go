@@ -0,0 +1,107 @@
+package esort
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Kind classifies an instruction by the kind of value it compares, so that
+// instructions of one kind can be adjusted together with [Sorter.ReverseKind].
+type Kind int
+
+const (
+	// KindOther is the kind of instructions that compare derived or composite
+	// values, such as bands, ranks, or network prefixes.
+	KindOther = Kind(iota)
+	// KindBool is the kind of instructions that compare booleans.
+	KindBool
+	// KindInteger is the kind of instructions that compare integers of any
+	// size and signedness.
+	KindInteger
+	// KindFloat is the kind of instructions that compare floating-point,
+	// complex, or decimal numbers, such as [Sorter.ByScaledInt].
+	KindFloat
+	// KindString is the kind of instructions that compare strings or the
+	// textual components of values in any manner, such as [Sorter.ByString],
+	// [Sorter.ByStringFold], and [Sorter.ByURLHost].  Instructions that compare
+	// values derived from strings, such as [Sorter.ByLengthBand] and
+	// [Sorter.ByPath], are of kind KindOther.
+	KindString
+	// KindBytes is the kind of instructions that compare byte slices.
+	KindBytes
+	// KindTime is the kind of instructions that compare times.
+	KindTime
+	// KindFunc is the kind of instructions that compare with an arbitrary
+	// function, such as [Sorter.ByFunc] and [Sorter.ByFuncCmp], including
+	// those added by the subpackages.
+	KindFunc
+)
+
+var kindNames = [...]string{
+	KindOther:   "other",
+	KindBool:    "bool",
+	KindInteger: "integer",
+	KindFloat:   "float",
+	KindString:  "string",
+	KindBytes:   "bytes",
+	KindTime:    "time",
+	KindFunc:    "func",
+}
+
+// String returns the lowercase name of the kind, such as "string".
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// kindOf returns the Kind of instructions comparing values of the ordered type
+// K.  It tells the kinds apart by arithmetic rather than by reflection, which
+// also classifies defined types such as a Score defined as float64: only
+// strings concatenate, so that "A" plus "A" differs from the string of the rune
+// 'A'+'A', and only integers overflow to zero when one is doubled 64 times.
+func kindOf[K constraints.Ordered]() Kind {
+	// The runes are variables so that converting them to K is valid for every
+	// type in K's type set.
+	a, aa, one := rune('A'), rune('A'+'A'), rune(1)
+	if K(a)+K(a) != K(aa) {
+		return KindString
+	}
+	var zero K
+	v := K(one)
+	for i := 0; i < 64; i++ {
+		v += v
+	}
+	if v == zero {
+		return KindInteger
+	}
+	return KindFloat
+}
+
+// ReverseKind returns a copy of the Sorter with the direction of every
+// instruction of kind k reversed and the other instructions unchanged, such
+// as to reverse all text columns of a table while keeping its numeric ones:
+//
+//	sorter = sorter.ReverseKind(esort.KindString)
+//
+// Instructions derived with [Adapt] and [Pointers] keep their kinds.  Values
+// that an instruction places regardless of direction, such as nils under a
+// [NullPlacement] and strings that [Sorter.ByRegexpGroup] does not match, stay
+// where they are.
+func (s *Sorter[T]) ReverseKind(k Kind) *Sorter[T] {
+	c := *s
+	c.prog = append([]inst[T](nil), s.prog...)
+	for i := range c.prog {
+		if c.prog[i].Kind != k {
+			continue
+		}
+		if c.prog[i].Dir == Desc {
+			c.prog[i].Dir = Asc
+		} else {
+			c.prog[i].Dir = Desc
+		}
+	}
+	return &c
+}
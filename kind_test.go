@@ -0,0 +1,319 @@
+package esort
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestKindOf(t *testing.T) {
+	type (
+		score float64
+		name  string
+		level int8
+	)
+	for _, test := range []struct {
+		name string
+		got  Kind
+		want Kind
+	}{
+		{"int", kindOf[int](), KindInteger},
+		{"int8", kindOf[int8](), KindInteger},
+		{"int64", kindOf[int64](), KindInteger},
+		{"uint8", kindOf[uint8](), KindInteger},
+		{"uint64", kindOf[uint64](), KindInteger},
+		{"uintptr", kindOf[uintptr](), KindInteger},
+		{"defined integer", kindOf[level](), KindInteger},
+		{"float32", kindOf[float32](), KindFloat},
+		{"float64", kindOf[float64](), KindFloat},
+		{"defined float", kindOf[score](), KindFloat},
+		{"string", kindOf[string](), KindString},
+		{"defined string", kindOf[name](), KindString},
+	} {
+		if test.got != test.want {
+			t.Errorf("kindOf[%s]() = %v, want %v", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestKinds(t *testing.T) {
+	s := New[Data]().
+		ByBool(func(d Data) bool { return d.Bool }, Asc).
+		ByInt8(func(d Data) int8 { return d.Int8 }, Asc).
+		ByFloat64(func(d Data) float64 { return d.Float64 }, Asc).
+		ByStringFold(func(d Data) string { return d.String }, Asc).
+		ByBytes(func(d Data) []byte { return nil }, Asc).
+		ByTime(func(d Data) time.Time { return time.Time{} }, Asc).
+		ByFuncCmp(func(l, r Data) int { return 0 }, Asc).
+		ByBand(func(d Data) float64 { return d.Float64 }, nil, Asc)
+	want := []Kind{KindBool, KindInteger, KindFloat, KindString, KindBytes, KindTime, KindFunc, KindOther}
+	for _, got := range [][]inst[Data]{s.prog, Adapt(s, func(d Data) Data { return d }).prog} {
+		var kinds []Kind
+		for _, o := range got {
+			kinds = append(kinds, o.Kind)
+		}
+		if diff := cmp.Diff(want, kinds); diff != "" {
+			t.Errorf("instruction kinds = %v, want %v\n\ndiff (-want, +got):\n%v", kinds, want, diff)
+		}
+	}
+	if got := Pointers(s).prog[3].Kind; got != KindString {
+		t.Errorf("Pointers(s).prog[3].Kind = %v, want %v", got, KindString)
+	}
+}
+
+func TestKindsByMethod(t *testing.T) {
+	var (
+		s    = New[Data]()
+		m    = func() *MutableSorter[Data] { return NewMutable[Data]() }
+		str  = func(d Data) string { return d.String }
+		tm   = func(d Data) time.Time { return time.Time{} }
+		f64  = func(d Data) float64 { return d.Float64 }
+		cmpf = func(l, r Data) int { return 0 }
+		less = func(l, r Data) bool { return false }
+		last = func(s *Sorter[Data]) Kind { return s.prog[len(s.prog)-1].Kind }
+	)
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		want Kind
+	}{
+		{"ByBool", s.ByBool(func(d Data) bool { return d.Bool }, Asc), KindBool},
+		{"ByInt8", s.ByInt8(func(d Data) int8 { return d.Int8 }, Asc), KindInteger},
+		{"ByInt16", s.ByInt16(func(d Data) int16 { return d.Int16 }, Asc), KindInteger},
+		{"ByInt32", s.ByInt32(func(d Data) int32 { return d.Int32 }, Asc), KindInteger},
+		{"ByInt64", s.ByInt64(func(d Data) int64 { return d.Int64 }, Asc), KindInteger},
+		{"ByUint8", s.ByUint8(func(d Data) uint8 { return d.Uint8 }, Asc), KindInteger},
+		{"ByUint16", s.ByUint16(func(d Data) uint16 { return d.Uint16 }, Asc), KindInteger},
+		{"ByUint32", s.ByUint32(func(d Data) uint32 { return d.Uint32 }, Asc), KindInteger},
+		{"ByUint64", s.ByUint64(func(d Data) uint64 { return d.Uint64 }, Asc), KindInteger},
+		{"ByFloat32", s.ByFloat32(func(d Data) float32 { return d.Float32 }, Asc), KindFloat},
+		{"ByFloat64", s.ByFloat64(f64, Asc), KindFloat},
+		{"ByByte", s.ByByte(func(d Data) byte { return d.Byte }, Asc), KindInteger},
+		{"ByRune", s.ByRune(func(d Data) rune { return d.Rune }, Asc), KindInteger},
+		{"ByUint", s.ByUint(func(d Data) uint { return d.Uint }, Asc), KindInteger},
+		{"ByInt", s.ByInt(func(d Data) int { return d.Int }, Asc), KindInteger},
+		{"ByPointer", s.ByPointer(func(d Data) uintptr { return d.Pointer }, Asc), KindInteger},
+		{"ByString", s.ByString(str, Asc), KindString},
+		{"ByBytes", s.ByBytes(func(d Data) []byte { return d.Bytes }, Asc), KindBytes},
+		{"ByStringField", s.ByStringField(str), KindString},
+		{"ByIntField", s.ByIntField(func(d Data) int { return d.Int }), KindInteger},
+		{"ByFloat64Field", s.ByFloat64Field(f64), KindFloat},
+		{"ByFunc", s.ByFunc(less, Asc), KindFunc},
+		{"ByFuncStable", s.ByFuncStable(less, Asc), KindFunc},
+		{"ByFuncCmp", s.ByFuncCmp(cmpf, Asc), KindFunc},
+		{"ByLessStrict", s.ByLessStrict(less, Asc), KindFunc},
+		{"ByCmpChain", s.ByCmpChain(cmpf, Asc), KindFunc},
+		{"ByFuncCtx", s.ByFuncCtx(func(ctx context.Context, l, r Data) int { return 0 }, Asc), KindFunc},
+		{"ByBranch", s.ByBranch(func(d Data) bool { return d.Bool }, nil, nil), KindOther},
+		{"ByFirstRuneCategory", s.ByFirstRuneCategory(str, nil, Asc), KindOther},
+		{"ByScaledInt", s.ByScaledInt(func(d Data) int64 { return d.Int64 }, func(d Data) int32 { return d.Int32 }, Asc), KindFloat},
+		{"ByBigFloat", s.ByBigFloat(func(d Data) *big.Float { return nil }, NullsLast, Asc), KindFloat},
+		{"ByFloat64TotalOrder", s.ByFloat64TotalOrder(f64, Asc), KindFloat},
+		{"ByComplex128RealImag", s.ByComplex128RealImag(func(d Data) complex128 { return 0 }, Asc), KindFloat},
+		{"ByFloat64Money", s.ByFloat64Money(f64, Asc), KindFloat},
+		{"ByContentHash", s.ByContentHash(func(d Data) []byte { return d.Bytes }, Asc), KindOther},
+		{"ByWeightedLottery", s.ByWeightedLottery(f64, rand.New(rand.NewSource(1)), Asc), KindOther},
+		{"ByPriorityList", ByPriorityList(s, str, nil, NullsLast, Asc), KindOther},
+		{"ByScore", ByScore(s, f64, Asc), KindFloat},
+		{"ByKey", ByKey(s, str, Asc), KindString},
+		{"ByFuncKey", ByFuncKey(s, f64, Asc), KindFloat},
+		{"ByNonZeroFirst", ByNonZeroFirst(s, str, Desc), KindOther},
+		{"ByLargest", ByLargest(s, f64), KindFloat},
+		{"BySmallest", BySmallest(s, func(d Data) int { return d.Int }), KindInteger},
+		{"ByAlphabetical", s.ByAlphabetical(str), KindString},
+		{"ByReverseAlphabetical", s.ByReverseAlphabetical(str), KindString},
+		{"ByBand", s.ByBand(f64, nil, Asc), KindOther},
+		{"ByTopoRank", ByTopoRank(s, str, nil, Asc), KindOther},
+		{"ByBoolFlags", s.ByBoolFlags(nil, Asc), KindOther},
+		{"ByOrdinal", ByOrdinal(s, func(d Data) int8 { return d.Int8 }, Asc), KindInteger},
+		{"ByURLHost", s.ByURLHost(func(d Data) *url.URL { return nil }, NullsLast, Asc), KindString},
+		{"ByURLPath", s.ByURLPath(func(d Data) *url.URL { return nil }, NullsLast, Asc), KindString},
+		{"ByPrefix", s.ByPrefix(func(d Data) netip.Prefix { return netip.Prefix{} }, Asc), KindOther},
+		{"ByNullableBool", s.ByNullableBool(func(d Data) *bool { return nil }, [3]BoolState{BoolUnset, BoolFalse, BoolTrue}, Asc), KindBool},
+		{"ByNullableFloat64Bucket", ByNullableFloat64Bucket(s, func(d Data) *float64 { return nil }, 1, NullsLast, Asc), KindFloat},
+		{"ByNullableStringFold", s.ByNullableStringFold(func(d Data) *string { return nil }, NullsLast, Asc), KindString},
+		{"ByPath", s.ByPath(func(d Data) []string { return nil }, Asc), KindOther},
+		{"ByArray", ByArray(s, func(d Data) []int { return nil }, Asc), KindOther},
+		{"ByKeySlice", ByKeySlice(s, func(d Data) []int { return nil }, Asc), KindOther},
+		{"ByByteArray16", s.ByByteArray16(func(d Data) [16]byte { return [16]byte{} }, Asc), KindBytes},
+		{"ByByteArray32", s.ByByteArray32(func(d Data) [32]byte { return [32]byte{} }, Asc), KindBytes},
+		{"ByStringFold", s.ByStringFold(str, Asc), KindString},
+		{"ByNumericSuffix", s.ByNumericSuffix(str, Asc), KindString},
+		{"ByDottedVersion", s.ByDottedVersion(str, Asc), KindString},
+		{"ByProductName", s.ByProductName(str, Asc), KindString},
+		{"ByMySQLCI", s.ByMySQLCI(str, Asc), KindString},
+		{"ByIntegerString", s.ByIntegerString(str, Asc), KindString},
+		{"ByStringUTF16", s.ByStringUTF16(str, Asc), KindString},
+		{"ByPrefixGroup", s.ByPrefixGroup(str, 1, Asc), KindString},
+		{"ByRegexpGroup", s.ByRegexpGroup(str, regexp.MustCompile(`(.)`), 1, Asc), KindString},
+		{"ByTrailingNumberFirst", s.ByTrailingNumberFirst(str, Asc), KindString},
+		{"ByStringPrefixAware", s.ByStringPrefixAware(str, true, Asc), KindString},
+		{"ByBase36", s.ByBase36(str, Asc), KindString},
+		{"ByLengthBand", s.ByLengthBand(str, []int{5}, Asc), KindOther},
+		{"ByTime", s.ByTime(tm, Asc), KindTime},
+		{"ByTimePtr", s.ByTimePtr(func(d Data) *time.Time { return nil }, NullsLast, Asc), KindTime},
+		{"ByTimeRounded", s.ByTimeRounded(tm, time.Second, Asc), KindTime},
+		{"ByDeadline", s.ByDeadline(tm, time.Time{}, Asc), KindTime},
+		{"ByDayOfYear", s.ByDayOfYear(tm, time.UTC, Asc), KindTime},
+		{"ByRecencyBucket", s.ByRecencyBucket(tm, time.Time{}, nil, Asc), KindTime},
+		{"ByTimeCoalesce", s.ByTimeCoalesce([]func(Data) time.Time{tm}, Asc), KindTime},
+		{"ByNanosecondOfSecond", s.ByNanosecondOfSecond(tm, Asc), KindTime},
+		{"MutableSorter.ByBool", m().ByBool(func(d Data) bool { return d.Bool }, Asc).Freeze(), KindBool},
+		{"MutableSorter.ByInt8", m().ByInt8(func(d Data) int8 { return d.Int8 }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByInt16", m().ByInt16(func(d Data) int16 { return d.Int16 }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByInt32", m().ByInt32(func(d Data) int32 { return d.Int32 }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByInt64", m().ByInt64(func(d Data) int64 { return d.Int64 }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByUint8", m().ByUint8(func(d Data) uint8 { return d.Uint8 }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByUint16", m().ByUint16(func(d Data) uint16 { return d.Uint16 }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByUint32", m().ByUint32(func(d Data) uint32 { return d.Uint32 }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByUint64", m().ByUint64(func(d Data) uint64 { return d.Uint64 }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByFloat32", m().ByFloat32(func(d Data) float32 { return d.Float32 }, Asc).Freeze(), KindFloat},
+		{"MutableSorter.ByFloat64", m().ByFloat64(f64, Asc).Freeze(), KindFloat},
+		{"MutableSorter.ByByte", m().ByByte(func(d Data) byte { return d.Byte }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByRune", m().ByRune(func(d Data) rune { return d.Rune }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByUint", m().ByUint(func(d Data) uint { return d.Uint }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByInt", m().ByInt(func(d Data) int { return d.Int }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByPointer", m().ByPointer(func(d Data) uintptr { return d.Pointer }, Asc).Freeze(), KindInteger},
+		{"MutableSorter.ByString", m().ByString(str, Asc).Freeze(), KindString},
+		{"MutableSorter.ByBytes", m().ByBytes(func(d Data) []byte { return d.Bytes }, Asc).Freeze(), KindBytes},
+		{"MutableSorter.ByFunc", m().ByFunc(less, Asc).Freeze(), KindFunc},
+		{"MutableSorter.ByFuncCmp", m().ByFuncCmp(cmpf, Asc).Freeze(), KindFunc},
+	} {
+		if got := last(test.s); got != test.want {
+			t.Errorf("%s instruction kind = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestReverseKind(t *testing.T) {
+	in := []Data{
+		{Int: 1, String: "a", Float64: 2},
+		{Int: 0, String: "b", Float64: 1},
+		{Int: 1, String: "b", Float64: 1},
+		{Int: 0, String: "a", Float64: 2},
+		{Int: 1, String: "a", Float64: 1},
+	}
+	base := New[Data]().
+		ByInt(func(d Data) int { return d.Int }, Asc).
+		ByString(func(d Data) string { return d.String }, Asc).
+		ByFloat64(func(d Data) float64 { return d.Float64 }, Desc)
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		want *Sorter[Data]
+	}{
+		{
+			name: "strings",
+			s:    base.ReverseKind(KindString),
+			want: New[Data]().
+				ByInt(func(d Data) int { return d.Int }, Asc).
+				ByString(func(d Data) string { return d.String }, Desc).
+				ByFloat64(func(d Data) float64 { return d.Float64 }, Desc),
+		},
+		{
+			name: "numbers",
+			s:    base.ReverseKind(KindInteger).ReverseKind(KindFloat),
+			want: New[Data]().
+				ByInt(func(d Data) int { return d.Int }, Desc).
+				ByString(func(d Data) string { return d.String }, Asc).
+				ByFloat64(func(d Data) float64 { return d.Float64 }, Asc),
+		},
+		{
+			name: "absent kind",
+			s:    base.ReverseKind(KindTime),
+			want: base,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			want := slices.Clone(in)
+			slices.SortFunc(want, test.want.Less)
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(want, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+			}
+		})
+	}
+	if got := base.prog[1].Dir; got != Asc {
+		t.Errorf("base.prog[1].Dir = %v after ReverseKind, want %v", got, Asc)
+	}
+}
+
+func TestReverseKindNulls(t *testing.T) {
+	in := []Data{
+		{Int: 2, String: "x-2", Float64: 2},
+		{Int: 0, String: "none"},
+		{Int: 1, String: "x-1", Float64: 1},
+		{Int: 3, String: "x-3", Float64: 3},
+	}
+	timeOf := func(d Data) *time.Time {
+		if d.Int == 0 {
+			return nil
+		}
+		t := time.Unix(int64(d.Int), 0)
+		return &t
+	}
+	strOf := func(d Data) *string {
+		if d.Int == 0 {
+			return nil
+		}
+		return &d.String
+	}
+	floatOf := func(d Data) *float64 {
+		if d.Int == 0 {
+			return nil
+		}
+		return &d.Float64
+	}
+	str := func(d Data) string { return d.String }
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		out  []Data
+	}{
+		{
+			name: "ByTimePtr",
+			s:    New[Data]().ByTimePtr(timeOf, NullsLast, Asc).ReverseKind(KindTime),
+			out:  []Data{in[3], in[0], in[2], in[1]},
+		},
+		{
+			name: "ByNullableStringFold",
+			s:    New[Data]().ByNullableStringFold(strOf, NullsFirst, Asc).ReverseKind(KindString),
+			out:  []Data{in[1], in[3], in[0], in[2]},
+		},
+		{
+			name: "ByNullableFloat64Bucket",
+			s:    ByNullableFloat64Bucket(New[Data](), floatOf, 0, NullsLast, Asc).ReverseKind(KindFloat),
+			out:  []Data{in[3], in[0], in[2], in[1]},
+		},
+		{
+			name: "ByRegexpGroup",
+			s:    New[Data]().ByRegexpGroup(str, regexp.MustCompile(`^x-(\d+)$`), 1, Asc).ReverseKind(KindString),
+			out:  []Data{in[3], in[0], in[2], in[1]},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestKindString(t *testing.T) {
+	for k, want := range map[Kind]string{KindString: "string", KindFunc: "func", Kind(42): "Kind(42)"} {
+		if got := k.String(); got != want {
+			t.Errorf("Kind(%d).String() = %q, want %q", int(k), got, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package esort
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Label names the most recently added instruction, such as after the field it
+// sorts by, for [Sorter.MarshalText].  Like the By methods, Label copies the
+// Sorter.  It panics if the Sorter has no instructions.
+//
+//	sorter := esort.New[Person]().
+//		ByString(func(p Person) string { return p.GivenName }, esort.Desc).Label("name").
+//		ByInt(func(p Person) int { return p.ID }, esort.Asc).Label("id")
+func (s *Sorter[T]) Label(name string) *Sorter[T] {
+	if len(s.prog) == 0 {
+		panic(errNoProgram)
+	}
+	c := *s
+	c.prog = append([]inst[T](nil), s.prog...)
+	c.prog[len(c.prog)-1].Label = name
+	return &c
+}
+
+// unlabeled is the placeholder MarshalText emits for instructions without a
+// label.
+const unlabeled = "_"
+
+// MarshalText encodes the labels and directions of the instructions, such as
+// "name:desc,id:asc", which suits logging the configuration of a Sorter.
+// Instructions without a label are emitted as "_".  It returns an error if a
+// label contains ':' or ',', which would make the encoding ambiguous.
+func (s *Sorter[T]) MarshalText() ([]byte, error) {
+	var b strings.Builder
+	for i, o := range s.prog {
+		if strings.ContainsAny(o.Label, ":,") {
+			return nil, fmt.Errorf("esort: label %q of instruction %d contains ':' or ','", o.Label, i)
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		label := o.Label
+		if label == "" {
+			label = unlabeled
+		}
+		b.WriteString(label)
+		b.WriteByte(':')
+		b.WriteString(o.Dir.String())
+	}
+	return []byte(b.String()), nil
+}
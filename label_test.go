@@ -0,0 +1,69 @@
+package esort
+
+import "testing"
+
+func TestMarshalText(t *testing.T) {
+	byInt := func(d Data) int { return d.Int }
+	byString := func(d Data) string { return d.String }
+	base := New[Data]().ByString(byString, Desc).Label("name")
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		want string
+	}{
+		{
+			name: "empty",
+			s:    New[Data](),
+			want: "",
+		},
+		{
+			name: "labeled",
+			s:    base.ByInt(byInt, Asc).Label("id"),
+			want: "name:desc,id:asc",
+		},
+		{
+			name: "unlabeled",
+			s:    base.ByInt(byInt, Asc),
+			want: "name:desc,_:asc",
+		},
+		{
+			name: "relabeled",
+			s:    base.Label("given_name"),
+			want: "given_name:desc",
+		},
+		{
+			name: "adapted",
+			s:    Adapt(base, func(d Data) Data { return d }),
+			want: "name:desc",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.s.MarshalText()
+			if err != nil {
+				t.Fatalf("s.MarshalText() = _, %v, want nil error", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("s.MarshalText() = %q, want %q", got, test.want)
+			}
+		})
+	}
+	if got, err := base.MarshalText(); err != nil || string(got) != "name:desc" {
+		t.Errorf("base.MarshalText() = %q, %v, want %q, nil (labeling must copy)", got, err, "name:desc")
+	}
+}
+
+func TestMarshalTextInvalidLabel(t *testing.T) {
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Asc).Label("a:b")
+	if _, err := s.MarshalText(); err == nil {
+		t.Errorf("s.MarshalText() = _, nil, want error")
+	}
+}
+
+func TestLabelEmpty(t *testing.T) {
+	defer func() {
+		if got, want := recover(), errNoProgram; got != want {
+			t.Errorf("New().Label() panic = %v, want %v", got, want)
+		}
+	}()
+	New[Data]().Label("name")
+}
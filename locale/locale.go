@@ -0,0 +1,119 @@
+// Package locale provides sorting instructions for [esort.Sorter] that depend
+// on locale-specific formatting conventions.
+package locale
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/matttproud/esort"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// separators are the grouping and decimal separators of a locale.
+type separators struct {
+	Group, Decimal string
+}
+
+// separatorsFor determines the separators of tag by formatting a number with
+// both grouping and a fractional part and extracting the non-digit runs.
+func separatorsFor(tag language.Tag) separators {
+	s := message.NewPrinter(tag).Sprintf("%.1f", 1234567.5)
+	var runs []string
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+		if i < 0 {
+			break
+		}
+		s = s[i:]
+		j := strings.IndexFunc(s, unicode.IsDigit)
+		if j < 0 {
+			j = len(s)
+		}
+		runs = append(runs, s[:j])
+		s = s[j:]
+	}
+	var sep separators
+	switch len(runs) {
+	case 0:
+		sep.Decimal = "."
+	case 1:
+		sep.Decimal = runs[0]
+	default:
+		sep.Group, sep.Decimal = runs[0], runs[len(runs)-1]
+	}
+	return sep
+}
+
+// parse parses s as a number formatted according to sep.  Digits from any
+// Unicode decimal digit script are accepted.
+func parse(s string, sep separators) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if sep.Group != "" {
+		s = strings.ReplaceAll(s, sep.Group, "")
+		if strings.TrimSpace(sep.Group) == "" {
+			// Accept ordinary spaces where a locale groups with (narrow) no-break
+			// spaces, since users rarely type the latter.
+			s = strings.ReplaceAll(s, " ", "")
+		}
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], sep.Decimal) {
+			b.WriteByte('.')
+			i += len(sep.Decimal)
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+		switch {
+		case r == '-' || r == '−':
+			b.WriteByte('-')
+		case r == '+':
+			b.WriteByte('+')
+		case unicode.IsDigit(r):
+			b.WriteByte(byte('0' + digitValue(r)))
+		default:
+			return 0, false
+		}
+	}
+	v, err := strconv.ParseFloat(b.String(), 64)
+	return v, err == nil
+}
+
+// digitValue returns the value of the decimal digit r.  Unicode encodes the
+// decimal digits of each script as contiguous runs of ten starting at zero.
+func digitValue(r rune) int {
+	z := r
+	for unicode.IsDigit(z - 1) {
+		z--
+	}
+	return int(r-z) % 10
+}
+
+// ByLocaleNumber sorts the data by the numeric value of a given string
+// formatted according to the conventions of tag, such as "1.234,56" for
+// German or "1,234.56" for English.  Strings that cannot be parsed sort after
+// all numbers in ascending order and lexically among themselves.
+//
+// The strings are parsed upon each comparison.  Prefer parsing the numbers
+// once ahead of sorting and using [esort.Sorter.ByFloat64] for large data.
+func ByLocaleNumber[T any](s *esort.Sorter[T], f func(T) string, tag language.Tag, d esort.Dir) *esort.Sorter[T] {
+	sep := separatorsFor(tag)
+	fn := func(l, r T) bool {
+		ls, rs := f(l), f(r)
+		lv, lok := parse(ls, sep)
+		rv, rok := parse(rs, sep)
+		switch {
+		case lok && rok:
+			return lv < rv
+		case lok != rok:
+			return lok
+		}
+		return ls < rs
+	}
+	return s.ByFunc(fn, d)
+}
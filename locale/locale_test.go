@@ -0,0 +1,93 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matttproud/esort"
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/language"
+)
+
+func TestByLocaleNumber(t *testing.T) {
+	id := func(s string) string { return s }
+	for _, test := range []struct {
+		name    string
+		tag     language.Tag
+		d       esort.Dir
+		in, out []string
+	}{
+		{
+			name: "german asc",
+			tag:  language.German,
+			d:    esort.Asc,
+			in:   []string{"1.234,56", "n/a", "-3", "99,5", "1.234,5", "abc"},
+			out:  []string{"-3", "99,5", "1.234,5", "1.234,56", "abc", "n/a"},
+		},
+		{
+			name: "english asc",
+			tag:  language.English,
+			d:    esort.Asc,
+			in:   []string{"1,234.56", "n/a", "-3", "99.5", "1,234.5", "abc"},
+			out:  []string{"-3", "99.5", "1,234.5", "1,234.56", "abc", "n/a"},
+		},
+		{
+			name: "english desc",
+			tag:  language.English,
+			d:    esort.Desc,
+			in:   []string{"1,234.56", "-3", "99.5", "1,234.5"},
+			out:  []string{"1,234.56", "1,234.5", "99.5", "-3"},
+		},
+		{
+			name: "french",
+			tag:  language.French,
+			d:    esort.Asc,
+			in:   []string{"1 234,5", "12,5", "1 000"},
+			out:  []string{"12,5", "1 000", "1 234,5"},
+		},
+		{
+			name: "arabic digits",
+			tag:  language.Arabic,
+			d:    esort.Asc,
+			in:   []string{"١٬٢٣٤٫٥", "٩٩"},
+			out:  []string{"٩٩", "١٬٢٣٤٫٥"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByLocaleNumber(esort.New[string](), id, test.tag, test.d)
+			out := slices.Clone(test.in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByLocaleNumberEquivalent(t *testing.T) {
+	type amount struct {
+		ID   int
+		Text string
+	}
+	text := func(a amount) string { return a.Text }
+	id := func(a amount) int { return a.ID }
+	german := []amount{{0, "1.234,56"}, {1, "-0,5"}, {2, "1.234,56"}, {3, "12"}, {4, "1.234,5"}}
+	english := []amount{{0, "1,234.56"}, {1, "-0.5"}, {2, "1,234.56"}, {3, "12"}, {4, "1,234.5"}}
+	ids := func(tag language.Tag, in []amount) []int {
+		s := ByLocaleNumber(esort.New[amount](), text, tag, esort.Asc).ByInt(id, esort.Asc)
+		out := slices.Clone(in)
+		slices.SortFunc(out, s.Less)
+		var ids []int
+		for _, a := range out {
+			ids = append(ids, a.ID)
+		}
+		return ids
+	}
+	want := []int{1, 3, 4, 0, 2}
+	if diff := cmp.Diff(want, ids(language.German, german)); diff != "" {
+		t.Errorf("German order diff (-want, +got):\n%v", diff)
+	}
+	if diff := cmp.Diff(want, ids(language.English, english)); diff != "" {
+		t.Errorf("English order diff (-want, +got):\n%v", diff)
+	}
+}
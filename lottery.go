@@ -0,0 +1,79 @@
+package esort
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// ByWeightedLottery orders the data by a weighted random draw, such as to
+// rotate advertisements that tie on quality so that heavier ones appear first
+// more often:
+//
+//	sorter := esort.New[Ad]().
+//		ByInt(func(a Ad) int { return a.Quality }, esort.Desc).
+//		ByWeightedLottery(func(a Ad) float64 { return a.Weight }, rng, esort.Asc)
+//
+// Each element draws the key -ln(u)/weight(v) for a uniform u in (0, 1] from
+// rng, following Efraimidis and Spirakis, so in ascending order each element
+// sorts first among the elements it ties with at a probability proportional to
+// its weight.  Elements whose weight is not positive, including NaN, draw +Inf
+// and sort last in ascending order.
+//
+// The keys are drawn once per element at the start of each sort and released
+// when it ends, so they form a valid total order for the sort regardless of
+// the values of the elements.  Because the keys belong to positions in the
+// data, [Sorter.Sort] and [Sorter.SortE] sort like [Sorter.SortStable], and
+// [Sorter.SortParallel] sorts sequentially.  Outside of a sort, such as in
+// [Sorter.Less] and [Sorter.IsSorted], the instruction finds all elements
+// equal.  rng is used under a lock while drawing, so the Sorter remains safe
+// for concurrent use, but rng must not be used elsewhere at the same time.
+func (s *Sorter[T]) ByWeightedLottery(weight func(T) float64, rng *rand.Rand, d Dir) *Sorter[T] {
+	var mu sync.Mutex
+	draw := func(data []T) func(l, r T, li, ri int) int {
+		keys := make([]float64, len(data))
+		mu.Lock()
+		defer mu.Unlock()
+		for i, v := range data {
+			keys[i] = math.Inf(1)
+			if w := weight(v); w > 0 {
+				keys[i] = -math.Log(1-rng.Float64()) / w
+			}
+		}
+		c := compareFunc(func(i int) float64 { return keys[i] })
+		return func(_, _ T, li, ri int) int { return c(li, ri) }
+	}
+	return s.addInst(inst[T]{Cmp: func(T, T) int { return 0 }, Draw: draw, Dir: d})
+}
+
+// hasDraws reports whether the Sorter has an instruction with Draw set.
+func (s *Sorter[T]) hasDraws() bool {
+	for _, o := range s.prog {
+		if o.Draw != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// withDraws returns the Sorter with the instructions that have Draw set bound
+// to the keys drawn for data.  It returns s itself if there are no such
+// instructions.
+func (s *Sorter[T]) withDraws(data []T) *Sorter[T] {
+	var c *Sorter[T]
+	for i, o := range s.prog {
+		if o.Draw == nil {
+			continue
+		}
+		if c == nil {
+			cp := *s
+			cp.prog = append([]inst[T](nil), s.prog...)
+			c = &cp
+		}
+		c.prog[i].CmpAt = o.Draw(data)
+	}
+	if c == nil {
+		return s
+	}
+	return c
+}
@@ -0,0 +1,114 @@
+package esort
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestByWeightedLottery(t *testing.T) {
+	weights := map[string]float64{"heavy": 8, "medium": 2, "light": 1, "none": 0, "nan": math.NaN()}
+	weight := func(s string) float64 { return weights[s] }
+	in := []string{"none", "light", "nan", "medium", "heavy"}
+	const trials = 2000
+	first := make(map[string]int)
+	for seed := int64(0); seed < trials; seed++ {
+		s := New[string]().ByWeightedLottery(weight, rand.New(rand.NewSource(seed)), Asc)
+		out := slices.Clone(in)
+		s.Sort(out)
+		if got := out[len(out)-2:]; !slices.Equal(got, []string{"none", "nan"}) {
+			t.Fatalf("seed %d: s.Sort(%v) = %v, want non-positive weights last in original order", seed, in, out)
+		}
+		first[out[0]]++
+		// The same seed draws the same keys.
+		again := slices.Clone(in)
+		New[string]().ByWeightedLottery(weight, rand.New(rand.NewSource(seed)), Asc).SortStable(again)
+		if !slices.Equal(out, again) {
+			t.Fatalf("seed %d: s.SortStable(%v) = %v, want %v", seed, in, again, out)
+		}
+	}
+	// The expected shares of first places are 8/11, 2/11, and 1/11.
+	if !(first["heavy"] > first["medium"] && first["medium"] > first["light"]) {
+		t.Errorf("first places = %v, want heavy > medium > light", first)
+	}
+	if share := float64(first["heavy"]) / trials; share < 0.65 || share > 0.8 {
+		t.Errorf("heavy ranked first in %.2f of trials, want about %.2f", share, 8.0/11)
+	}
+}
+
+func TestByWeightedLotteryTieBreak(t *testing.T) {
+	in := []Data{
+		{Int: 1, String: "a"},
+		{Int: 2, String: "b"},
+		{Int: 1, String: "c"},
+		{Int: 2, String: "d"},
+		{Int: 3, String: "e"},
+	}
+	weight := func(Data) float64 { return 1 }
+	for seed := int64(0); seed < 100; seed++ {
+		s := New[Data]().
+			ByInt(func(d Data) int { return d.Int }, Desc).
+			ByWeightedLottery(weight, rand.New(rand.NewSource(seed)), Asc)
+		out := slices.Clone(in)
+		s.Sort(out)
+		var got []int
+		for _, d := range out {
+			got = append(got, d.Int)
+		}
+		if diff := cmp.Diff([]int{3, 2, 2, 1, 1}, got); diff != "" {
+			t.Fatalf("seed %d: s.Sort(%v) = %v, want earlier instructions to take precedence\n\ndiff (-want, +got):\n%v", seed, in, out, diff)
+		}
+		if !s.IsSorted(out) {
+			t.Errorf("seed %d: s.IsSorted(%v) = false, want true", seed, out)
+		}
+	}
+}
+
+func TestByWeightedLotteryDerived(t *testing.T) {
+	// Only "b" has weight, so it sorts first in ascending order, and the others
+	// draw +Inf and keep their original order.
+	weight := func(d Data) float64 {
+		if d.String == "b" {
+			return 1
+		}
+		return 0
+	}
+	in := []Data{{String: "a"}, {String: "b"}, {String: "c"}}
+	s := New[Data]().ByWeightedLottery(weight, rand.New(rand.NewSource(1)), Asc)
+
+	t.Run("Pointers", func(t *testing.T) {
+		a, b, c := in[0], in[1], in[2]
+		ptrs := []*Data{&a, nil, &b, &c}
+		Pointers(s).Sort(ptrs)
+		if want := []*Data{nil, &b, &a, &c}; !slices.Equal(ptrs, want) {
+			t.Errorf("Pointers(s).Sort(…) = %v, want %v", ptrs, want)
+		}
+	})
+	t.Run("Adapt", func(t *testing.T) {
+		type wrapper struct{ D Data }
+		var out []wrapper
+		for _, d := range in {
+			out = append(out, wrapper{d})
+		}
+		Adapt(s, func(w wrapper) Data { return w.D }).Sort(out)
+		want := []wrapper{{in[1]}, {in[0]}, {in[2]}}
+		if diff := cmp.Diff(want, out); diff != "" {
+			t.Errorf("Adapt(s, …).Sort(…) = %v, want %v\n\ndiff (-want, +got):\n%v", out, want, diff)
+		}
+	})
+	t.Run("WithCounters", func(t *testing.T) {
+		cs, c := s.WithCounters()
+		out := slices.Clone(in)
+		cs.Sort(out)
+		want := []Data{in[1], in[0], in[2]}
+		if diff := cmp.Diff(want, out); diff != "" {
+			t.Errorf("s.WithCounters().Sort(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+		}
+		if c.Decisions(0) == 0 {
+			t.Errorf("Counters.Decisions(0) = 0, want > 0")
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package esort
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// MapEntry is a key-value pair from a map.
+type MapEntry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// SortedEntries returns the entries of m sorted according to s.  Entries that
+// s considers equal are returned in an unspecified order, because map
+// iteration order is unspecified; include the key in s to make the result
+// deterministic.
+func SortedEntries[K comparable, V any](m map[K]V, s *Sorter[MapEntry[K, V]]) []MapEntry[K, V] {
+	out := make([]MapEntry[K, V], 0, len(m))
+	for k, v := range m {
+		out = append(out, MapEntry[K, V]{k, v})
+	}
+	slices.SortFunc(out, s.Less)
+	return out
+}
+
+// SortedKeys returns the keys of m sorted in direction d.
+func SortedKeys[K constraints.Ordered, V any](m map[K]V, d Dir) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	less := func(l, r K) bool { return l < r }
+	if d == Desc {
+		less = func(l, r K) bool { return r < l }
+	}
+	slices.SortFunc(keys, less)
+	return keys
+}
+
+// SortedByValue returns the keys of m ordered by their values according to
+// less, which follows the contract of [SortFunc], in direction d.  Keys whose
+// values are equal are returned in an unspecified order, because map
+// iteration order is unspecified; use [SortedEntries] with a Sorter that
+// includes the key to make the result deterministic.
+func SortedByValue[K comparable, V any](m map[K]V, less func(a, b V) bool, d Dir) []K {
+	s := New[MapEntry[K, V]]().
+		ByFunc(func(l, r MapEntry[K, V]) bool { return less(l.Value, r.Value) }, d)
+	entries := SortedEntries(m, s)
+	keys := make([]K, len(entries))
+	for i, e := range entries {
+		keys[i] = e.Key
+	}
+	return keys
+}
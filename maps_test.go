@@ -0,0 +1,85 @@
+package esort
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSortedEntries(t *testing.T) {
+	type entry = MapEntry[string, int]
+	s := New[entry]().
+		ByInt(func(e entry) int { return e.Value }, Desc).
+		ByString(func(e entry) string { return e.Key }, Asc)
+	for _, test := range []struct {
+		name string
+		in   map[string]int
+		out  []entry
+	}{
+		{
+			name: "empty",
+			in:   map[string]int{},
+			out:  []entry{},
+		},
+		{
+			name: "value desc key asc",
+			in:   map[string]int{"a": 1, "b": 3, "c": 2, "d": 3, "e": 1},
+			out: []entry{
+				{"b", 3},
+				{"d", 3},
+				{"c", 2},
+				{"a", 1},
+				{"e", 1},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := SortedEntries(test.in, s)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("SortedEntries(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		in   map[string]int
+		d    Dir
+		out  []string
+	}{
+		{"empty", map[string]int{}, Asc, []string{}},
+		{"nil", nil, Desc, []string{}},
+		{"asc", map[string]int{"b": 1, "c": 2, "a": 3}, Asc, []string{"a", "b", "c"}},
+		{"desc", map[string]int{"b": 1, "c": 2, "a": 3}, Desc, []string{"c", "b", "a"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := SortedKeys(test.in, test.d)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("SortedKeys(%v, %v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, test.d, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestSortedByValue(t *testing.T) {
+	less := func(a, b float64) bool { return a < b }
+	for _, test := range []struct {
+		name string
+		in   map[string]float64
+		d    Dir
+		out  []string
+	}{
+		{"empty", map[string]float64{}, Asc, []string{}},
+		{"asc", map[string]float64{"a": 2.5, "b": -1, "c": 10}, Asc, []string{"b", "a", "c"}},
+		{"desc", map[string]float64{"a": 2.5, "b": -1, "c": 10}, Desc, []string{"c", "a", "b"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := SortedByValue(test.in, less, test.d)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("SortedByValue(%v, less, %v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, test.d, out, test.out, diff)
+			}
+		})
+	}
+}
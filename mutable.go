@@ -0,0 +1,137 @@
+package esort
+
+import "bytes"
+
+// MutableSorter builds a sorting program in place.  Its By methods mirror
+// those of [Sorter] but append to the MutableSorter's own program instead of
+// copying it, which avoids an allocation per instruction when many Sorters are
+// built on hot paths.  Call Freeze to obtain the Sorter:
+//
+//	sorter := esort.NewMutable[Person]().
+//		ByString(func(p Person) string { return p.GivenName }, esort.Desc).
+//		ByInt(func(p Person) int { return p.ID }, esort.Asc).
+//		Freeze()
+//
+// A MutableSorter is not safe for concurrent use by multiple goroutines.
+type MutableSorter[T any] struct {
+	prog []inst[T]
+}
+
+// NewMutable creates a MutableSorter.
+func NewMutable[T any]() *MutableSorter[T] { return new(MutableSorter[T]) }
+
+// add appends an instruction to the program in place.
+func (m *MutableSorter[T]) add(o inst[T]) *MutableSorter[T] {
+	m.prog = append(m.prog, o)
+	return m
+}
+
+// Freeze returns a Sorter with the instructions added so far.  The Sorter
+// shares its instructions with m without copying them and is unaffected by
+// instructions that are later added to m.
+func (m *MutableSorter[T]) Freeze() *Sorter[T] {
+	return &Sorter[T]{prog: m.prog[:len(m.prog):len(m.prog)]}
+}
+
+// ByBool is like [Sorter.ByBool] but adds the instruction in place.
+func (m *MutableSorter[T]) ByBool(f func(T) bool, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareBoolFunc(f), Dir: d, Kind: KindBool})
+}
+
+// ByInt8 is like [Sorter.ByInt8] but adds the instruction in place.
+func (m *MutableSorter[T]) ByInt8(f func(T) int8, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByInt16 is like [Sorter.ByInt16] but adds the instruction in place.
+func (m *MutableSorter[T]) ByInt16(f func(T) int16, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByInt32 is like [Sorter.ByInt32] but adds the instruction in place.
+func (m *MutableSorter[T]) ByInt32(f func(T) int32, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByInt64 is like [Sorter.ByInt64] but adds the instruction in place.
+func (m *MutableSorter[T]) ByInt64(f func(T) int64, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByUint8 is like [Sorter.ByUint8] but adds the instruction in place.
+func (m *MutableSorter[T]) ByUint8(f func(T) uint8, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByUint16 is like [Sorter.ByUint16] but adds the instruction in place.
+func (m *MutableSorter[T]) ByUint16(f func(T) uint16, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByUint32 is like [Sorter.ByUint32] but adds the instruction in place.
+func (m *MutableSorter[T]) ByUint32(f func(T) uint32, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByUint64 is like [Sorter.ByUint64] but adds the instruction in place.
+func (m *MutableSorter[T]) ByUint64(f func(T) uint64, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByFloat32 is like [Sorter.ByFloat32] but adds the instruction in place.
+func (m *MutableSorter[T]) ByFloat32(f func(T) float32, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindFloat})
+}
+
+// ByFloat64 is like [Sorter.ByFloat64] but adds the instruction in place.
+func (m *MutableSorter[T]) ByFloat64(f func(T) float64, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindFloat})
+}
+
+// ByByte is like [Sorter.ByByte] but adds the instruction in place.
+func (m *MutableSorter[T]) ByByte(f func(T) byte, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByRune is like [Sorter.ByRune] but adds the instruction in place.
+func (m *MutableSorter[T]) ByRune(f func(T) rune, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByUint is like [Sorter.ByUint] but adds the instruction in place.
+func (m *MutableSorter[T]) ByUint(f func(T) uint, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByInt is like [Sorter.ByInt] but adds the instruction in place.
+func (m *MutableSorter[T]) ByInt(f func(T) int, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByPointer is like [Sorter.ByPointer] but adds the instruction in place.
+func (m *MutableSorter[T]) ByPointer(f func(T) uintptr, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindInteger})
+}
+
+// ByString is like [Sorter.ByString] but adds the instruction in place.
+func (m *MutableSorter[T]) ByString(f func(T) string, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: compareFunc(f), Dir: d, Kind: KindString})
+}
+
+// ByBytes is like [Sorter.ByBytes] but adds the instruction in place.
+func (m *MutableSorter[T]) ByBytes(f func(T) []byte, d Dir) *MutableSorter[T] {
+	fn := func(l, r T) int {
+		return bytes.Compare(f(l), f(r))
+	}
+	return m.add(inst[T]{Cmp: fn, Dir: d, Kind: KindBytes})
+}
+
+// ByFunc is like [Sorter.ByFunc] but adds the instruction in place.
+func (m *MutableSorter[T]) ByFunc(f SortFunc[T], d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Func: f, Dir: d, Kind: KindFunc})
+}
+
+// ByFuncCmp is like [Sorter.ByFuncCmp] but adds the instruction in place.
+func (m *MutableSorter[T]) ByFuncCmp(f func(l, r T) int, d Dir) *MutableSorter[T] {
+	return m.add(inst[T]{Cmp: f, Dir: d, Kind: KindFunc})
+}
@@ -0,0 +1,71 @@
+package esort
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestMutableSorter(t *testing.T) {
+	str := func(d Data) string { return d.String }
+	num := func(d Data) int { return d.Int }
+	in := []Data{
+		{String: "b", Int: 2},
+		{String: "a", Int: 3},
+		{String: "c", Int: 2},
+		{String: "a", Int: 1},
+	}
+	m := NewMutable[Data]().ByInt(num, Desc)
+	first := m.Freeze()
+	second := m.ByString(str, Asc).Freeze()
+	m.ByString(str, Desc)
+	isC := func(d Data) bool { return d.String == "c" }
+	for _, test := range []struct {
+		name      string
+		got, want *Sorter[Data]
+	}{
+		{"first", first, New[Data]().ByInt(num, Desc)},
+		{"second", second, New[Data]().ByInt(num, Desc).ByString(str, Asc)},
+		{"derived", second.ByBool(isC, Asc), New[Data]().ByInt(num, Desc).ByString(str, Asc).ByBool(isC, Asc)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			want := slices.Clone(in)
+			slices.SortStableFunc(want, test.want.Less)
+			got := slices.Clone(in)
+			slices.SortStableFunc(got, test.got.Less)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("slices.SortStableFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, want, diff)
+			}
+			if !test.got.SameShape(test.want) {
+				t.Error("got.SameShape(want) = false, want true")
+			}
+		})
+	}
+}
+
+func BenchmarkBuild(b *testing.B) {
+	str := func(d Data) string { return d.String }
+	num := func(d Data) int { return d.Int }
+	b.Run("Sorter", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			New[Data]().
+				ByInt(num, Desc).
+				ByString(str, Asc).
+				ByInt(num, Asc).
+				ByString(str, Desc)
+		}
+	})
+	b.Run("MutableSorter", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			NewMutable[Data]().
+				ByInt(num, Desc).
+				ByString(str, Asc).
+				ByInt(num, Asc).
+				ByString(str, Desc).
+				Freeze()
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package esort
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// compareURL returns a comparison function comparing the URLs returned by f
+// by the component returned by c.  Nil URLs are placed according to nulls and
+// tie with one another.
+func compareURL[T any](f func(T) *url.URL, c func(*url.URL) string, nulls NullPlacement) func(l, r T, d Dir) int {
+	return func(l, r T, d Dir) int {
+		lu, ru := f(l), f(r)
+		if less, ok := nullLess(lu == nil, ru == nil, nulls, d); ok {
+			switch {
+			case less:
+				return -1
+			case (lu == nil) == (ru == nil):
+				return 0
+			}
+			return 1
+		}
+		return strings.Compare(c(lu), c(ru))
+	}
+}
+
+// ByURLHost sorts the data by the host name of a given URL as reported by
+// [url.URL.Hostname], which excludes any port.  Nil URLs are placed according
+// to nulls and tie with one another.
+func (s *Sorter[T]) ByURLHost(f func(T) *url.URL, nulls NullPlacement, d Dir) *Sorter[T] {
+	fn := compareURL(f, (*url.URL).Hostname, nulls)
+	return s.addInst(inst[T]{CmpDir: fn, Dir: d, Kind: KindString})
+}
+
+// ByURLPath sorts the data by the path of a given URL as reported by
+// [url.URL.EscapedPath].  Nil URLs are placed according to nulls and tie with
+// one another.
+func (s *Sorter[T]) ByURLPath(f func(T) *url.URL, nulls NullPlacement, d Dir) *Sorter[T] {
+	fn := compareURL(f, (*url.URL).EscapedPath, nulls)
+	return s.addInst(inst[T]{CmpDir: fn, Dir: d, Kind: KindString})
+}
+
+// comparePrefix compares l and r by masked address, then by length, then by
+// unmasked address.
+func comparePrefix(l, r netip.Prefix) int {
+	if c := l.Masked().Addr().Compare(r.Masked().Addr()); c != 0 {
+		return c
+	}
+	switch {
+	case l.Bits() < r.Bits():
+		return -1
+	case l.Bits() > r.Bits():
+		return 1
+	}
+	return l.Addr().Compare(r.Addr())
+}
+
+// ByPrefix sorts the data by a given IP network prefix as in a routing table:
+// by network address and then by prefix length, so that in ascending order a
+// prefix sorts right before the more specific prefixes it contains, such as
+// 10.0.0.0/8 before 10.1.0.0/16 before 192.168.0.0/16.  Host bits beyond the
+// prefix length are ignored except to break ties, so 10.1.2.3/8 sorts right
+// after 10.0.0.0/8.
+//
+// Addresses are compared with [netip.Addr.Compare], so IPv4 prefixes sort
+// before all IPv6 prefixes rather than interleaving with them, and an
+// IPv4-mapped IPv6 prefix such as ::ffff:10.0.0.0/104 sorts among the IPv6
+// ones.  Prefixes have no zones.  Invalid prefixes, such as the zero
+// netip.Prefix, sort before all valid ones in ascending order.
+func (s *Sorter[T]) ByPrefix(f func(T) netip.Prefix, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return comparePrefix(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d})
+}
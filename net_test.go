@@ -0,0 +1,145 @@
+package esort
+
+import (
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func mustParseURL(t *testing.T, s string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatalf("url.Parse(%q) = _, %v, want nil error", s, err)
+	}
+	return u
+}
+
+func TestByURL(t *testing.T) {
+	urls := []*url.URL{
+		mustParseURL(t, "https://b.example.com/a"),
+		mustParseURL(t, "https://a.example.com:8080/b"),
+		nil,
+		mustParseURL(t, "https://a.example.com/a%20b"),
+		mustParseURL(t, "https://b.example.com/"),
+	}
+	str := func(us []*url.URL) []string {
+		var out []string
+		for _, u := range us {
+			if u == nil {
+				out = append(out, "<nil>")
+				continue
+			}
+			out = append(out, u.String())
+		}
+		return out
+	}
+	id := func(u *url.URL) *url.URL { return u }
+	for _, test := range []struct {
+		name string
+		s    *Sorter[*url.URL]
+		out  []string
+	}{
+		{
+			name: "host asc path asc",
+			s:    New[*url.URL]().ByURLHost(id, NullsFirst, Asc).ByURLPath(id, NullsFirst, Asc),
+			out: []string{
+				"<nil>",
+				"https://a.example.com/a%20b",
+				"https://a.example.com:8080/b",
+				"https://b.example.com/",
+				"https://b.example.com/a",
+			},
+		},
+		{
+			name: "host desc path asc",
+			s:    New[*url.URL]().ByURLHost(id, NullsLast, Desc).ByURLPath(id, NullsLast, Asc),
+			out: []string{
+				"https://b.example.com/",
+				"https://b.example.com/a",
+				"https://a.example.com/a%20b",
+				"https://a.example.com:8080/b",
+				"<nil>",
+			},
+		},
+		{
+			name: "host asc nulls last",
+			s:    New[*url.URL]().ByURLHost(id, NullsLast, Asc).ByURLPath(id, NullsLast, Asc),
+			out: []string{
+				"https://a.example.com/a%20b",
+				"https://a.example.com:8080/b",
+				"https://b.example.com/",
+				"https://b.example.com/a",
+				"<nil>",
+			},
+		},
+		{
+			name: "host desc nulls first",
+			s:    New[*url.URL]().ByURLHost(id, NullsFirst, Desc).ByURLPath(id, NullsFirst, Asc),
+			out: []string{
+				"<nil>",
+				"https://b.example.com/",
+				"https://b.example.com/a",
+				"https://a.example.com/a%20b",
+				"https://a.example.com:8080/b",
+			},
+		},
+		{
+			name: "path desc",
+			s:    New[*url.URL]().ByURLPath(id, NullsLast, Desc).ByURLHost(id, NullsLast, Asc),
+			out: []string{
+				"https://a.example.com:8080/b",
+				"https://a.example.com/a%20b",
+				"https://b.example.com/a",
+				"https://b.example.com/",
+				"<nil>",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("normal", func(t *testing.T) {
+				out := slices.Clone(urls)
+				slices.SortFunc(out, test.s.Less)
+				if diff := cmp.Diff(test.out, str(out)); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", str(urls), str(out), test.out, diff)
+				}
+			})
+			t.Run("inverse", func(t *testing.T) {
+				out := slices.Clone(urls)
+				reverse(out)
+				slices.SortFunc(out, test.s.Less)
+				if diff := cmp.Diff(test.out, str(out)); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", str(urls), str(out), test.out, diff)
+				}
+			})
+		})
+	}
+}
+
+func TestByPrefix(t *testing.T) {
+	in := []string{"192.168.0.0/16", "::ffff:10.0.0.0/104", "10.1.0.0/16", "2001:db8::/32", "10.1.2.3/8", "10.0.0.0/8", "invalid"}
+	prefix := func(s string) netip.Prefix {
+		p, _ := netip.ParsePrefix(s)
+		return p
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"invalid", "10.0.0.0/8", "10.1.2.3/8", "10.1.0.0/16", "192.168.0.0/16", "::ffff:10.0.0.0/104", "2001:db8::/32"}},
+		{"desc", Desc, []string{"2001:db8::/32", "::ffff:10.0.0.0/104", "192.168.0.0/16", "10.1.0.0/16", "10.1.2.3/8", "10.0.0.0/8", "invalid"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByPrefix(prefix, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Package normalize provides sorting instructions for [esort.Sorter] that
+// compare strings under Unicode normalization.
+package normalize
+
+import (
+	"strings"
+
+	"github.com/matttproud/esort"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ByNFC sorts the data by a given string value after normalizing it to
+// Unicode Normalization Form C, so that canonically equivalent strings tie:
+// "é" as the single rune U+00E9 and as "e" followed by the combining acute
+// accent U+0301 are equal.
+//
+// The strings are normalized upon each comparison.  Strings that are already
+// in NFC are detected without allocating, but all others are copied each time
+// they are compared.  On hot paths, prefer normalizing the strings once ahead
+// of sorting with [norm.NFC] and using [esort.Sorter.ByString].
+func ByNFC[T any](s *esort.Sorter[T], f func(T) string, d esort.Dir) *esort.Sorter[T] {
+	fn := func(l, r T) int {
+		return strings.Compare(norm.NFC.String(f(l)), norm.NFC.String(f(r)))
+	}
+	return s.ByFuncCmp(fn, d)
+}
@@ -0,0 +1,49 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matttproud/esort"
+	"golang.org/x/exp/slices"
+)
+
+func TestByNFC(t *testing.T) {
+	type word struct {
+		ID   int
+		Text string
+	}
+	const (
+		nfc = "caf\u00e9"
+		nfd = "cafe\u0301"
+	)
+	text := func(w word) string { return w.Text }
+	id := func(w word) int { return w.ID }
+	for _, test := range []struct {
+		name    string
+		d       esort.Dir
+		in, out []word
+	}{
+		{
+			name: "asc",
+			d:    esort.Asc,
+			in:   []word{{0, nfd}, {1, "cafd"}, {2, nfc}, {3, "caff"}, {4, nfd}},
+			out:  []word{{1, "cafd"}, {3, "caff"}, {0, nfd}, {2, nfc}, {4, nfd}},
+		},
+		{
+			name: "desc",
+			d:    esort.Desc,
+			in:   []word{{0, nfd}, {1, "cafd"}, {2, nfc}, {3, "caff"}, {4, nfd}},
+			out:  []word{{0, nfd}, {2, nfc}, {4, nfd}, {3, "caff"}, {1, "cafd"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByNFC(esort.New[word](), text, test.d).ByInt(id, esort.Asc)
+			out := slices.Clone(test.in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package esort
+
+import (
+	"fmt"
+	"math"
+)
+
+// NullPlacement determines where absent values, such as nil pointers or keys
+// that are not known to an instruction, are placed relative to present ones.
+// The placement holds regardless of the direction of the instruction.
+type NullPlacement int
+
+const (
+	// NullsFirst places absent values before present ones.
+	NullsFirst = NullPlacement(iota)
+	// NullsLast places absent values after present ones.
+	NullsLast
+)
+
+// nullsFirst reports whether absent values must compare as less than present
+// ones for an instruction sorting in direction d, which accounts for Less
+// swapping its operands for descending instructions.
+func nullsFirst(p NullPlacement, d Dir) bool {
+	return (p == NullsFirst) == (d == Asc)
+}
+
+// nullLess orders absent values relative to present ones according to p for an
+// instruction sorting in direction d.  It reports whether the pair was decided,
+// which is the case unless both values are present.
+func nullLess(lNull, rNull bool, p NullPlacement, d Dir) (less, ok bool) {
+	switch {
+	case !lNull && !rNull:
+		return false, false
+	case lNull && rNull:
+		return false, true
+	}
+	return lNull == nullsFirst(p, d), true
+}
+
+// BoolState is one of the three states of a nullable boolean.
+type BoolState int
+
+const (
+	// BoolUnset is the state of a nil *bool.
+	BoolUnset = BoolState(iota)
+	// BoolFalse is the state of a *bool pointing to false.
+	BoolFalse
+	// BoolTrue is the state of a *bool pointing to true.
+	BoolTrue
+)
+
+// boolState returns the BoolState of b.
+func boolState(b *bool) BoolState {
+	switch {
+	case b == nil:
+		return BoolUnset
+	case *b:
+		return BoolTrue
+	}
+	return BoolFalse
+}
+
+// ByNullableBool sorts the data by a given nullable boolean value.  The states
+// are ordered as listed in order, which must contain each BoolState exactly
+// once; ByNullableBool panics otherwise.  For instance, to place unset values
+// last and true before false in ascending order:
+//
+//	sorter := esort.New[Setting]().
+//		ByNullableBool(func(s Setting) *bool { return s.Enabled }, [3]esort.BoolState{esort.BoolTrue, esort.BoolFalse, esort.BoolUnset}, esort.Asc)
+func (s *Sorter[T]) ByNullableBool(f func(T) *bool, order [3]BoolState, d Dir) *Sorter[T] {
+	var rank [3]int
+	var seen [3]bool
+	for i, st := range order {
+		if st < BoolUnset || st > BoolTrue || seen[st] {
+			panic(fmt.Errorf("esort: order %v is not a permutation of BoolUnset, BoolFalse, and BoolTrue", order))
+		}
+		seen[st] = true
+		rank[st] = i
+	}
+	key := func(v T) int { return rank[boolState(f(v))] }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d, Kind: KindBool})
+}
+
+// ByNullableFloat64Bucket sorts the data by a given nullable float64 value
+// bucketed by width, so that noisy readings within the same bucket tie.  A
+// present value v falls into bucket math.Floor(v / width), which keeps the
+// ordering transitive, unlike comparing values within a tolerance of each
+// other.  Nil values and NaNs are both treated as absent: they are placed
+// according to nulls and tie with one another.  If width is not positive, the
+// present values are compared without bucketing.
+func ByNullableFloat64Bucket[T any](s *Sorter[T], f func(T) *float64, width float64, nulls NullPlacement, d Dir) *Sorter[T] {
+	fn := func(l, r T, d Dir) int {
+		lv, rv := f(l), f(r)
+		lNull, rNull := lv == nil || math.IsNaN(*lv), rv == nil || math.IsNaN(*rv)
+		if less, ok := nullLess(lNull, rNull, nulls, d); ok {
+			switch {
+			case less:
+				return -1
+			case lNull == rNull:
+				return 0
+			}
+			return 1
+		}
+		lb, rb := *lv, *rv
+		if width > 0 {
+			lb, rb = math.Floor(lb/width), math.Floor(rb/width)
+		}
+		switch {
+		case lb < rb:
+			return -1
+		case rb < lb:
+			return 1
+		}
+		return 0
+	}
+	return s.addInst(inst[T]{CmpDir: fn, Dir: d, Kind: KindFloat})
+}
+
+// ByNullableStringFold sorts the data by a given nullable string value
+// case-insensitively like [Sorter.ByStringFold], such as an optional middle
+// name.  Nil values are placed according to nulls and tie with one another.
+// A pointer to the empty string is present and sorts before other present
+// values in ascending order.
+func (s *Sorter[T]) ByNullableStringFold(f func(T) *string, nulls NullPlacement, d Dir) *Sorter[T] {
+	fn := func(l, r T, d Dir) int {
+		lv, rv := f(l), f(r)
+		if less, ok := nullLess(lv == nil, rv == nil, nulls, d); ok {
+			switch {
+			case less:
+				return -1
+			case (lv == nil) == (rv == nil):
+				return 0
+			}
+			return 1
+		}
+		return compareFold(*lv, *rv)
+	}
+	return s.addInst(inst[T]{CmpDir: fn, Dir: d, Kind: KindString})
+}
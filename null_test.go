@@ -0,0 +1,166 @@
+package esort
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestByNullableBool(t *testing.T) {
+	var (
+		yes = true
+		no  = false
+	)
+	type flag struct {
+		Name  string
+		Value *bool
+	}
+	value := func(f flag) *bool { return f.Value }
+	name := func(f flag) string { return f.Name }
+	in := []flag{
+		{"a", &yes},
+		{"b", nil},
+		{"c", &no},
+		{"d", &yes},
+		{"e", nil},
+		{"f", &no},
+	}
+	for _, test := range []struct {
+		name  string
+		order [3]BoolState
+		d     Dir
+		out   []string
+	}{
+		{
+			name:  "unset false true asc",
+			order: [3]BoolState{BoolUnset, BoolFalse, BoolTrue},
+			d:     Asc,
+			out:   []string{"b", "e", "c", "f", "a", "d"},
+		},
+		{
+			name:  "true false unset asc",
+			order: [3]BoolState{BoolTrue, BoolFalse, BoolUnset},
+			d:     Asc,
+			out:   []string{"a", "d", "c", "f", "b", "e"},
+		},
+		{
+			name:  "false unset true desc",
+			order: [3]BoolState{BoolFalse, BoolUnset, BoolTrue},
+			d:     Desc,
+			out:   []string{"a", "d", "b", "e", "c", "f"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[flag]().ByNullableBool(value, test.order, test.d).ByString(name, Asc)
+			out := slices.Clone(in)
+			reverse(out)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, f := range out {
+				got = append(got, f.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByNullableBoolInvalidOrder(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ByNullableBool with duplicate states did not panic")
+		}
+	}()
+	New[Data]().ByNullableBool(func(Data) *bool { return nil }, [3]BoolState{BoolTrue, BoolTrue, BoolFalse}, Asc)
+}
+
+func TestByNullableFloat64Bucket(t *testing.T) {
+	type reading struct {
+		Name  string
+		Value *float64
+	}
+	ptr := func(v float64) *float64 { return &v }
+	value := func(r reading) *float64 { return r.Value }
+	name := func(r reading) string { return r.Name }
+	in := []reading{
+		{"b", ptr(1.04)},
+		{"nil", nil},
+		{"d", ptr(2)},
+		{"c", ptr(1.06)},
+		{"nan", ptr(math.NaN())},
+		{"a", ptr(1.01)},
+		{"z", ptr(0.5)},
+	}
+	for _, test := range []struct {
+		name  string
+		width float64
+		nulls NullPlacement
+		d     Dir
+		out   []string
+	}{
+		{"nulls last asc", 0.05, NullsLast, Asc, []string{"z", "a", "b", "c", "d", "nan", "nil"}},
+		{"nulls first asc", 0.05, NullsFirst, Asc, []string{"nan", "nil", "z", "a", "b", "c", "d"}},
+		{"nulls last desc", 0.05, NullsLast, Desc, []string{"d", "c", "a", "b", "z", "nan", "nil"}},
+		{"nulls first desc", 0.05, NullsFirst, Desc, []string{"nan", "nil", "d", "c", "a", "b", "z"}},
+		{"wide buckets", 1, NullsLast, Desc, []string{"d", "a", "b", "c", "z", "nan", "nil"}},
+		{"no buckets", 0, NullsLast, Desc, []string{"d", "c", "b", "a", "z", "nan", "nil"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByNullableFloat64Bucket(New[reading](), value, test.width, test.nulls, test.d).ByString(name, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, r := range out {
+				got = append(got, r.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(…) = %v, want %v\n\ndiff (-want, +got):\n%v", got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByNullableStringFold(t *testing.T) {
+	type profile struct {
+		ID     int
+		Middle *string
+	}
+	ptr := func(s string) *string { return &s }
+	middle := func(p profile) *string { return p.Middle }
+	id := func(p profile) int { return p.ID }
+	in := []profile{
+		{1, nil},
+		{2, ptr("Bob")},
+		{3, ptr("bob")},
+		{4, ptr("alice")},
+		{5, nil},
+		{6, ptr("Carol")},
+	}
+	for _, test := range []struct {
+		name  string
+		nulls NullPlacement
+		d     Dir
+		out   []int
+	}{
+		{"nulls last asc", NullsLast, Asc, []int{4, 2, 3, 6, 1, 5}},
+		{"nulls first asc", NullsFirst, Asc, []int{1, 5, 4, 2, 3, 6}},
+		{"nulls last desc", NullsLast, Desc, []int{6, 2, 3, 4, 1, 5}},
+		{"nulls first desc", NullsFirst, Desc, []int{1, 5, 6, 2, 3, 4}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[profile]().ByNullableStringFold(middle, test.nulls, test.d).ByInt(id, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []int
+			for _, p := range out {
+				got = append(got, p.ID)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(…) = %v, want %v\n\ndiff (-want, +got):\n%v", got, test.out, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package esort
+
+import (
+	"runtime"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+// parallelMinLen is the length below which SortParallel sorts sequentially,
+// because coordinating goroutines costs more than it saves.
+const parallelMinLen = 4096
+
+// NotParallelSafe returns a copy of the Sorter that [Sorter.SortParallel]
+// sorts sequentially.  Use it when a function given to an instruction is not
+// safe to call from multiple goroutines at once, such as one that reuses a
+// shared buffer or caches results in a map without locking.  It has no effect
+// on the other sorting functions, which never call the instructions
+// concurrently.
+func (s *Sorter[T]) NotParallelSafe() *Sorter[T] {
+	c := *s
+	c.parallelUnsafe = true
+	return &c
+}
+
+// SortParallel sorts the data in place according to the Sorter using up to
+// GOMAXPROCS goroutines: it sorts contiguous parts of the data concurrently
+// and then merges them pairwise.  It allocates a buffer the size of the data.
+// Like [slices.SortFunc], SortParallel does not preserve the relative order of
+// equal elements.
+//
+// The functions given to the instructions are called from multiple goroutines
+// at once.  SortParallel sorts sequentially if the Sorter was created with
+// [Sorter.NotParallelSafe], if it has an instruction added with
+// [Sorter.ByWeightedLottery], or if the data is small.  A panic raised by an
+// instruction is re-raised on the calling goroutine like in [Sorter.Sort],
+// including as a descriptive error if the Sorter was created with
+// [Sorter.RecoverExtractorPanics].
+func (s *Sorter[T]) SortParallel(data []T) {
+	n := runtime.GOMAXPROCS(0)
+	if s.parallelUnsafe || s.hasDraws() || n < 2 || len(data) < parallelMinLen {
+		s.Sort(data)
+		return
+	}
+	// bounds delimits the runs: run i is data[bounds[i]:bounds[i+1]].
+	bounds := make([]int, 0, n+1)
+	for i := 0; i < n; i++ {
+		bounds = append(bounds, i*len(data)/n)
+	}
+	bounds = append(bounds, len(data))
+	var w workers
+	for i := 0; i < n; i++ {
+		run := data[bounds[i]:bounds[i+1]]
+		w.Go(func() { slices.SortFunc(run, s.Less) })
+	}
+	s.wait(&w)
+	src, dst := data, make([]T, len(data))
+	for len(bounds) > 2 {
+		next := make([]int, 0, len(bounds)/2+1)
+		for i := 0; i+1 < len(bounds); i += 2 {
+			lo := bounds[i]
+			next = append(next, lo)
+			if i+2 == len(bounds) {
+				// An odd run out has no partner to merge with.
+				copy(dst[lo:], src[lo:])
+				break
+			}
+			mid, hi := bounds[i+1], bounds[i+2]
+			w.Go(func() { s.merge2(dst[lo:hi], src[lo:mid], src[mid:hi]) })
+		}
+		s.wait(&w)
+		bounds = append(next, len(data))
+		src, dst = dst, src
+	}
+	if &src[0] != &data[0] {
+		copy(data, src)
+	}
+}
+
+// workers runs functions on their own goroutines and records the first panic
+// that any of them raises.
+type workers struct {
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	panicked bool
+	v        any
+}
+
+// Go calls f on a new goroutine.
+func (w *workers) Go(f func()) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				w.mu.Lock()
+				defer w.mu.Unlock()
+				if !w.panicked {
+					w.panicked, w.v = true, r
+				}
+			}
+		}()
+		f()
+	}()
+}
+
+// wait waits for the functions started by w to return and re-raises the
+// first panic among them on the calling goroutine as Sort would.
+func (s *Sorter[T]) wait(w *workers) {
+	w.wg.Wait()
+	if !w.panicked {
+		return
+	}
+	if s.recoverPanics {
+		panic(extractorPanic(w.v))
+	}
+	panic(w.v)
+}
+
+// merge2 merges the sorted runs l and r into dst, which has room for both.
+func (s *Sorter[T]) merge2(dst, l, r []T) {
+	i := 0
+	for len(l) > 0 && len(r) > 0 {
+		if s.Less(r[0], l[0]) {
+			dst[i], r = r[0], r[1:]
+		} else {
+			dst[i], l = l[0], l[1:]
+		}
+		i++
+	}
+	i += copy(dst[i:], l)
+	copy(dst[i:], r)
+}
@@ -0,0 +1,121 @@
+package esort
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestSortParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	for _, n := range []int{0, 1, 100, parallelMinLen, 3*parallelMinLen + 7} {
+		in := make([]Data, n)
+		for i := range in {
+			in[i] = Data{Int: i * 7919 % 1009, Uint: uint(i)}
+		}
+		s := New[Data]().
+			ByInt(func(d Data) int { return d.Int }, Desc).
+			ByUint(func(d Data) uint { return d.Uint }, Asc)
+		want := slices.Clone(in)
+		slices.SortFunc(want, s.Less)
+		got := slices.Clone(in)
+		s.SortParallel(got)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("s.SortParallel(%d elements) differs from slices.SortFunc\n\ndiff (-want, +got):\n%v", n, diff)
+		}
+	}
+}
+
+// concurrencyProbe returns an int key for Data that records the largest number
+// of its calls running at once in max.  It yields while running so that calls
+// from other goroutines can overlap with it even on a single CPU.
+func concurrencyProbe(max *atomic.Int64) func(Data) int {
+	var inFlight atomic.Int64
+	return func(d Data) int {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := max.Load()
+			if n <= m || max.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		runtime.Gosched()
+		return d.Int
+	}
+}
+
+func TestSortParallelConcurrency(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	in := make([]Data, 4*parallelMinLen)
+	for i := range in {
+		in[i] = Data{Int: i * 7919 % 1009}
+	}
+	var max atomic.Int64
+	s := New[Data]().ByInt(concurrencyProbe(&max), Asc)
+	got := slices.Clone(in)
+	s.SortParallel(got)
+	if !s.IsSorted(got) {
+		t.Error("s.SortParallel(…) did not sort the data")
+	}
+	if got := max.Load(); got < 2 {
+		t.Errorf("maximum concurrent calls of the instruction = %d, want at least 2", got)
+	}
+}
+
+func TestSortParallelNotParallelSafe(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	in := make([]Data, 4*parallelMinLen)
+	for i := range in {
+		in[i] = Data{Int: i * 7919 % 1009}
+	}
+	var max atomic.Int64
+	s := New[Data]().ByInt(concurrencyProbe(&max), Asc).NotParallelSafe()
+	got := slices.Clone(in)
+	s.SortParallel(got)
+	if !s.IsSorted(got) {
+		t.Error("s.SortParallel(…) did not sort the data")
+	}
+	if got, want := max.Load(), int64(1); got != want {
+		t.Errorf("maximum concurrent calls of the instruction = %d, want %d", got, want)
+	}
+}
+
+func TestSortParallelPanic(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	key := func(d Data) int {
+		if d.Int == 0 {
+			panic("zero key")
+		}
+		return d.Int
+	}
+	s := New[Data]().ByInt(key, Asc)
+	for _, n := range []int{100, 4 * parallelMinLen} {
+		in := make([]Data, n)
+		for i := range in {
+			in[i] = Data{Int: i*7919%1009 + 1}
+		}
+		in[n/2].Int = 0
+		for _, test := range []struct {
+			name string
+			s    *Sorter[Data]
+			want string
+		}{
+			{"propagated", s, "zero key"},
+			{"recovered", s.RecoverExtractorPanics(), "esort: extractor panicked: zero key"},
+		} {
+			t.Run(fmt.Sprintf("%s %d", test.name, n), func(t *testing.T) {
+				defer func() {
+					if got := fmt.Sprint(recover()); got != test.want {
+						t.Errorf("s.SortParallel(…) panic = %v, want %v", got, test.want)
+					}
+				}()
+				test.s.SortParallel(slices.Clone(in))
+			})
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Package prototime provides sorting instructions for [esort.Sorter] on the
+// protocol buffer well-known time types.  It is separate from package esort so
+// that only its users depend on the protocol buffer runtime.
+package prototime
+
+import (
+	"github.com/matttproud/esort"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// compareTimestamp compares two present timestamps by their seconds and then
+// by their nanoseconds.
+func compareTimestamp(l, r *timestamppb.Timestamp) int {
+	switch {
+	case l.GetSeconds() < r.GetSeconds():
+		return -1
+	case l.GetSeconds() > r.GetSeconds():
+		return 1
+	case l.GetNanos() < r.GetNanos():
+		return -1
+	case l.GetNanos() > r.GetNanos():
+		return 1
+	}
+	return 0
+}
+
+// ByProtoTimestamp sorts the data by a given timestamp, comparing its Seconds
+// and then its Nanos fields directly rather than converting it to a
+// [time.Time], so the full range of the message is supported.  Nil timestamps
+// are placed according to nulls regardless of d and tie with one another.
+// Timestamps are not validated.
+func ByProtoTimestamp[T any](s *esort.Sorter[T], f func(T) *timestamppb.Timestamp, nulls esort.NullPlacement, d esort.Dir) *esort.Sorter[T] {
+	// The Sorter swaps the operands of descending instructions, so nil must
+	// compare as less for nulls first in ascending order and nulls last in
+	// descending order.
+	nilLess := (nulls == esort.NullsFirst) == (d == esort.Asc)
+	fn := func(l, r T) int {
+		lt, rt := f(l), f(r)
+		switch {
+		case lt == nil && rt == nil:
+			return 0
+		case lt == nil:
+			if nilLess {
+				return -1
+			}
+			return 1
+		case rt == nil:
+			if nilLess {
+				return 1
+			}
+			return -1
+		}
+		return compareTimestamp(lt, rt)
+	}
+	return s.ByFuncCmp(fn, d)
+}
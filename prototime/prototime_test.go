@@ -0,0 +1,59 @@
+package prototime
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matttproud/esort"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestByProtoTimestamp(t *testing.T) {
+	type event struct {
+		Name string
+		At   *timestamppb.Timestamp
+	}
+	at := func(e event) *timestamppb.Timestamp { return e.At }
+	name := func(e event) string { return e.Name }
+	in := []event{
+		{"far future", &timestamppb.Timestamp{Seconds: 253402300799, Nanos: 999999999}},
+		{"nil1", nil},
+		{"epoch", &timestamppb.Timestamp{}},
+		{"beyond time.Time", &timestamppb.Timestamp{Seconds: math.MaxInt64}},
+		{"before epoch", &timestamppb.Timestamp{Seconds: -1, Nanos: 500000000}},
+		{"epoch plus nano", &timestamppb.Timestamp{Nanos: 1}},
+		{"nil2", nil},
+	}
+	asc := []string{"before epoch", "epoch", "epoch plus nano", "far future", "beyond time.Time"}
+	desc := slices.Clone(asc)
+	for i, j := 0, len(desc)-1; i < j; i, j = i+1, j-1 {
+		desc[i], desc[j] = desc[j], desc[i]
+	}
+	nils := []string{"nil1", "nil2"}
+	for _, test := range []struct {
+		name  string
+		nulls esort.NullPlacement
+		d     esort.Dir
+		out   []string
+	}{
+		{"nulls first asc", esort.NullsFirst, esort.Asc, append(slices.Clone(nils), asc...)},
+		{"nulls last asc", esort.NullsLast, esort.Asc, append(slices.Clone(asc), nils...)},
+		{"nulls first desc", esort.NullsFirst, esort.Desc, append(slices.Clone(nils), desc...)},
+		{"nulls last desc", esort.NullsLast, esort.Desc, append(slices.Clone(desc), nils...)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByProtoTimestamp(esort.New[event](), at, test.nulls, test.d).ByString(name, esort.Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, e := range out {
+				got = append(got, e.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
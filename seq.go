@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package esort
+
+import (
+	"iter"
+	"sync"
+)
+
+// SortedSeq returns a sequence of the elements of the data in the order of the
+// Sorter, leaving the data unmodified:
+//
+//	for p := range sorter.SortedSeq(people) {
+//		…
+//	}
+//
+// The data is copied and sorted like [Sorter.SortStable] when the sequence is
+// first iterated, so equal elements keep their original order and the data
+// must not be modified until then.  Later iterations, which may run
+// concurrently, reuse the sorted copy.  A sequence that is never iterated
+// costs nothing.
+func (s *Sorter[T]) SortedSeq(data []T) iter.Seq[T] {
+	var (
+		once   sync.Once
+		sorted []T
+	)
+	return func(yield func(T) bool) {
+		once.Do(func() {
+			sorted = append([]T(nil), data...)
+			s.SortStable(sorted)
+		})
+		for _, v := range sorted {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
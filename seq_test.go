@@ -0,0 +1,52 @@
+//go:build go1.23
+
+package esort
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSortedSeq(t *testing.T) {
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Desc)
+	for _, test := range []struct {
+		name string
+		in   []Data
+	}{
+		{"nil", nil},
+		{"single", []Data{{Int: 1}}},
+		{"ties", []Data{{Int: 1, String: "a"}, {Int: 3}, {Int: 1, String: "b"}, {Int: 2}, {Int: 1, String: "c"}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			orig := slices.Clone(test.in)
+			want := slices.Clone(test.in)
+			s.SortStable(want)
+			seq := s.SortedSeq(test.in)
+			for i := 0; i < 2; i++ {
+				got := slices.Collect(seq)
+				if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("iteration %d of s.SortedSeq(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", i, test.in, got, want, diff)
+				}
+			}
+			if diff := cmp.Diff(orig, test.in); diff != "" {
+				t.Errorf("s.SortedSeq modified its input\n\ndiff (-want, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestSortedSeqStop(t *testing.T) {
+	s := New[int]().ByInt(func(v int) int { return v }, Asc)
+	var got []int
+	for v := range s.SortedSeq([]int{5, 3, 9, 1}) {
+		if got = append(got, v); len(got) == 2 {
+			break
+		}
+	}
+	if want := []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("first two elements = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package esort
+
+import (
+	"bytes"
+
+	"golang.org/x/exp/constraints"
+)
+
+// compareSlice compares l and r lexicographically element by element.  A
+// slice that is a prefix of the other sorts first.
+func compareSlice[E constraints.Ordered](l, r []E) int {
+	for i := 0; i < len(l) && i < len(r); i++ {
+		switch {
+		case l[i] < r[i]:
+			return -1
+		case r[i] < l[i]:
+			return 1
+		}
+	}
+	switch {
+	case len(l) < len(r):
+		return -1
+	case len(r) < len(l):
+		return 1
+	}
+	return 0
+}
+
+// ByPath sorts the data by a given hierarchical path, such as the segments of
+// a file path or the keys from the root of a tree to a node.  Paths are
+// compared segment by segment, so that siblings sort lexically by their
+// differing segment regardless of the length of their descendants' paths.  An
+// ancestor sorts before all of its descendants in ascending order, so sorting
+// a tree's nodes by their paths yields a pre-order traversal:
+//
+//	[a]
+//	[a b]
+//	[a b c]
+//	[a c]
+//	[b]
+func (s *Sorter[T]) ByPath(f func(T) []string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareSlice(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d})
+}
+
+// ByArray sorts the data by a given fixed-size array compared element by
+// element.  Go cannot abstract over array lengths, so f returns the array as a
+// slice:
+//
+//	sorter := esort.ByArray(esort.New[Host](), func(h Host) []uint16 { return h.Ports[:] }, esort.Asc)
+//
+// Slices of unequal length are compared as in [Sorter.ByPath].  For byte
+// arrays of 16 and 32 bytes, such as UUIDs and SHA-256 hashes, prefer
+// [Sorter.ByByteArray16] and [Sorter.ByByteArray32].
+func ByArray[T any, E constraints.Ordered](s *Sorter[T], f func(T) []E, d Dir) *Sorter[T] {
+	return ByKeySlice(s, f, d)
+}
+
+// ByKeySlice sorts the data by a variable-length key computed from each
+// element by f, such as the path of a node computed on the fly.  The keys are
+// compared lexicographically element by element, and a key that is a prefix of
+// another sorts first in ascending order, as in [Sorter.ByPath]:
+//
+//	sorter := esort.ByKeySlice(esort.New[Node](), func(n Node) []int { return n.IndexPath() }, esort.Asc)
+//
+// f is called twice per comparison, so a key that is costly to compute or
+// allocates is better computed once per element ahead of the sort and stored
+// alongside it.
+func ByKeySlice[T any, K constraints.Ordered](s *Sorter[T], f func(T) []K, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareSlice(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d})
+}
+
+// ByByteArray16 sorts the data by a given 16-byte array, such as a UUID or an
+// MD5 hash, compared lexically as by [bytes.Compare] without allocating.
+func (s *Sorter[T]) ByByteArray16(f func(T) [16]byte, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		lv, rv := f(l), f(r)
+		return bytes.Compare(lv[:], rv[:])
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindBytes})
+}
+
+// ByByteArray32 sorts the data by a given 32-byte array, such as a SHA-256
+// hash, compared lexically as by [bytes.Compare] without allocating.
+func (s *Sorter[T]) ByByteArray32(f func(T) [32]byte, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		lv, rv := f(l), f(r)
+		return bytes.Compare(lv[:], rv[:])
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindBytes})
+}
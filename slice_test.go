@@ -0,0 +1,149 @@
+package esort
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestByPath(t *testing.T) {
+	split := func(s string) []string {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(s, "/")
+	}
+	in := []string{"b", "a/c", "a/b/c", "", "a", "a/b", "ab", "a/b/a"}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"", "a", "a/b", "a/b/a", "a/b/c", "a/c", "ab", "b"}},
+		{"desc", Desc, []string{"b", "ab", "a/c", "a/b/c", "a/b/a", "a/b", "a", ""}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByPath(split, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByArray(t *testing.T) {
+	type host struct {
+		Name string
+		Addr [4]byte
+	}
+	addr := func(h host) []byte { return h.Addr[:] }
+	in := []host{
+		{"b", [4]byte{10, 0, 0, 2}},
+		{"c", [4]byte{192, 168, 0, 1}},
+		{"a", [4]byte{10, 0, 0, 1}},
+		{"d", [4]byte{9, 255, 255, 255}},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"d", "a", "b", "c"}},
+		{"desc", Desc, []string{"c", "b", "a", "d"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByArray(New[host](), addr, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, h := range out {
+				got = append(got, h.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByKeySlice(t *testing.T) {
+	keys := map[string][]int{
+		"root":  nil,
+		"a":     {1},
+		"a.b":   {1, 2},
+		"a.b.c": {1, 2, 3},
+		"a.c":   {1, 10},
+		"b":     {2},
+		"b.a":   {2, 0},
+	}
+	key := func(s string) []int { return keys[s] }
+	in := []string{"b.a", "a.c", "root", "a.b.c", "b", "a", "a.b"}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"root", "a", "a.b", "a.b.c", "a.c", "b", "b.a"}},
+		{"desc", Desc, []string{"b.a", "b", "a.c", "a.b.c", "a.b", "a", "root"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := ByKeySlice(New[string](), key, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByByteArray(t *testing.T) {
+	in := []string{"a", "b", "c", "d", "e", "f"}
+	want16 := slices.Clone(in)
+	slices.SortFunc(want16, func(l, r string) bool {
+		lh, rh := md5.Sum([]byte(l)), md5.Sum([]byte(r))
+		return string(lh[:]) < string(rh[:])
+	})
+	want32 := slices.Clone(in)
+	slices.SortFunc(want32, func(l, r string) bool {
+		lh, rh := sha256.Sum256([]byte(l)), sha256.Sum256([]byte(r))
+		return string(lh[:]) < string(rh[:])
+	})
+	s16 := New[string]().ByByteArray16(func(s string) [16]byte { return md5.Sum([]byte(s)) }, Asc)
+	s32 := New[string]().ByByteArray32(func(s string) [32]byte { return sha256.Sum256([]byte(s)) }, Desc)
+	reverse(want32)
+	for _, test := range []struct {
+		name string
+		s    *Sorter[string]
+		want []string
+	}{
+		{"16 asc", s16, want16},
+		{"32 desc", s32, want32},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(test.want, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.want, diff)
+			}
+		})
+	}
+
+	var a, b [16]byte
+	b[15] = 1
+	s := New[int]().ByByteArray16(func(i int) [16]byte {
+		if i == 0 {
+			return a
+		}
+		return b
+	}, Asc)
+	if n := testing.AllocsPerRun(100, func() { s.Less(0, 1) }); n != 0 {
+		t.Errorf("s.Less allocated %v times, want 0", n)
+	}
+}
@@ -0,0 +1,324 @@
+package esort
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/slices"
+)
+
+// indexed is an element decorated with its original position in the input.
+type indexed[T any] struct {
+	V T
+	I int
+}
+
+// SortStable sorts the data in place according to the Sorter, keeping equal
+// elements in their original input order regardless of the directions of the
+// instructions: a [Desc] instruction reverses the order of the values it
+// compares, not that of elements that tie under it.  Elements that tie under an
+// instruction added with [Sorter.ByFuncStable] are ordered by their original
+// position at that point in the program.
+//
+// SortStable allocates a copy of the data to track the original positions.
+func (s *Sorter[T]) SortStable(data []T) {
+	s = s.withDraws(data)
+	dec := make([]indexed[T], len(data))
+	for i, v := range data {
+		dec[i] = indexed[T]{v, i}
+	}
+	slices.SortFunc(dec, func(l, r indexed[T]) bool {
+		return s.lessIndexed(l.V, r.V, l.I, r.I)
+	})
+	for i, v := range dec {
+		data[i] = v.V
+	}
+}
+
+// SortReverseStable sorts the data in place in the reverse of the Sorter's
+// order, such that an element sorts before another if it sorts after it
+// according to the Sorter, while keeping elements that sort equally in their
+// original input order.  This differs from reversing the result of
+// [Sorter.SortStable], which would reverse the order of equal elements too.
+// Instructions added with [Sorter.ByFuncStable] are evaluated like those added
+// with [Sorter.ByFunc], so only elements that tie under all instructions are
+// ordered by position.
+//
+// Unlike SortStable, SortReverseStable does not allocate.
+func (s *Sorter[T]) SortReverseStable(data []T) {
+	slices.SortStableFunc(data, s.Greater)
+}
+
+// lessIndexed is like Less but breaks ties by the original positions li and
+// ri.
+func (s *Sorter[T]) lessIndexed(l, r T, li, ri int) bool {
+	if len(s.prog) == 0 {
+		panic(errNoProgram)
+	}
+	stable := false
+	for _, f := range s.prog {
+		l, r, li, ri := l, r, li, ri // Reset original ordering upon more than one cycle.
+		if f.Dir == Desc {
+			r, l, ri, li = l, r, li, ri
+		}
+		c := f.compare(l, r, f.Dir)
+		if c == 0 && f.CmpAt != nil {
+			c = f.CmpAt(l, r, li, ri)
+		}
+		if c != 0 {
+			return c < 0
+		}
+		if f.Stable {
+			stable = true
+			break
+		}
+	}
+	if s.fallback != nil && !stable {
+		if c := s.fallback(l, r); c != 0 {
+			return c < 0
+		}
+	}
+	return li < ri
+}
+
+// IsSorted reports whether the data is sorted according to the Sorter.
+func (s *Sorter[T]) IsSorted(data []T) bool {
+	return slices.IsSortedFunc(data, s.Less)
+}
+
+// IsSortedPrefix reports whether the first k elements of the data are sorted
+// according to the Sorter, such as the page of results about to be rendered.
+// The elements after them are not examined.  k is clamped to the range [0,
+// len(data)].
+func (s *Sorter[T]) IsSortedPrefix(data []T, k int) bool {
+	switch {
+	case k < 0:
+		k = 0
+	case k > len(data):
+		k = len(data)
+	}
+	return s.IsSorted(data[:k])
+}
+
+// SortChanged sorts the data in place according to the Sorter and reports
+// whether the order of the data changed.  Already sorted data is detected in a
+// single pass and left untouched.
+//
+// Like [slices.SortFunc], SortChanged does not preserve the relative order of
+// equal elements when it sorts.
+func (s *Sorter[T]) SortChanged(data []T) bool {
+	if s.IsSorted(data) {
+		return false
+	}
+	slices.SortFunc(data, s.Less)
+	return true
+}
+
+// RecoverExtractorPanics returns a copy of the Sorter whose [Sorter.Sort] and
+// [Sorter.SortE] recover panics raised by the functions given to its
+// instructions, such as a value accessor indexing out of range, and report
+// them as errors reading "esort: extractor panicked: …".  A panic that is an
+// error is wrapped, so it can be inspected with [errors.Is] and [errors.As].
+//
+// The panic is recovered once around the whole sort rather than around each
+// comparison, so the recovery adds no cost per comparison.  The data is left
+// partially sorted when a panic is recovered.  Less and the other sorting
+// functions are unaffected.
+func (s *Sorter[T]) RecoverExtractorPanics() *Sorter[T] {
+	c := *s
+	c.recoverPanics = true
+	return &c
+}
+
+// extractorPanic converts a value recovered from an instruction into an error.
+func extractorPanic(r any) error {
+	if err, ok := r.(error); ok {
+		if err == errNoProgram {
+			return err
+		}
+		return fmt.Errorf("esort: extractor panicked: %w", err)
+	}
+	return fmt.Errorf("esort: extractor panicked: %v", r)
+}
+
+// Sort sorts the data in place according to the Sorter.  If the Sorter was
+// created with [Sorter.RecoverExtractorPanics], a panic raised by an
+// instruction is re-raised as the descriptive error that SortE returns.
+func (s *Sorter[T]) Sort(data []T) {
+	if err := s.SortE(data); err != nil {
+		panic(err)
+	}
+}
+
+// SortE sorts the data in place according to the Sorter.  If the Sorter was
+// created with [Sorter.RecoverExtractorPanics], a panic raised by an
+// instruction is returned as an error; otherwise SortE always returns nil and
+// panics propagate.  A Sorter with an instruction added with
+// [Sorter.ByWeightedLottery] sorts like [Sorter.SortStable], which tracks the
+// positions that the instruction's keys are drawn for.
+func (s *Sorter[T]) SortE(data []T) (err error) {
+	if s.recoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				err = extractorPanic(r)
+			}
+		}()
+	}
+	if s.hasDraws() {
+		s.SortStable(data)
+		return nil
+	}
+	slices.SortFunc(data, s.Less)
+	return nil
+}
+
+// AuxSlice is a slice that [Sorter.SortWith] permutes alongside the data.  Use
+// [Aux] to create one.
+type AuxSlice interface {
+	// Len returns the length of the slice.
+	Len() int
+	// permute reorders the slice such that element i becomes the element
+	// previously at idx[i].
+	permute(idx []int)
+}
+
+// auxSlice is the AuxSlice for a slice of E.
+type auxSlice[E any] []E
+
+func (a auxSlice[E]) Len() int          { return len(a) }
+func (a auxSlice[E]) permute(idx []int) { permute([]E(a), idx) }
+
+// Aux wraps s for [Sorter.SortWith].
+func Aux[E any](s []E) AuxSlice { return auxSlice[E](s) }
+
+// SortWith sorts the data in place like [Sorter.SortStable] and applies the
+// same permutation to each of the auxiliary slices, which suits data held in
+// parallel slices:
+//
+//	err := sorter.SortWith(people, esort.Aux(tags), esort.Aux(scores))
+//
+// Elements of aux thus stay aligned with the elements of data they correspond
+// to, and ties keep their original relative order in all slices.  SortWith
+// returns an error without modifying any slice if the length of a slice in aux
+// differs from that of data.
+func (s *Sorter[T]) SortWith(data []T, aux ...AuxSlice) error {
+	for i, a := range aux {
+		if a.Len() != len(data) {
+			return fmt.Errorf("esort: auxiliary slice %d has length %d, want %d", i, a.Len(), len(data))
+		}
+	}
+	s = s.withDraws(data)
+	idx := make([]int, len(data))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortFunc(idx, func(l, r int) bool {
+		return s.lessIndexed(data[l], data[r], l, r)
+	})
+	permute(data, idx)
+	for _, a := range aux {
+		a.permute(idx)
+	}
+	return nil
+}
+
+// MinIndex returns the index of the element of the data that sorts first
+// according to the Sorter, or -1 if the data is empty.  Of several such
+// elements, the one with the lowest index is returned.  The data is scanned
+// once.
+func (s *Sorter[T]) MinIndex(data []T) int {
+	if len(data) == 0 {
+		return -1
+	}
+	min := 0
+	for i := 1; i < len(data); i++ {
+		if s.Less(data[i], data[min]) {
+			min = i
+		}
+	}
+	return min
+}
+
+// MaxIndex returns the index of the element of the data that sorts last
+// according to the Sorter, or -1 if the data is empty.  Of several such
+// elements, the one with the lowest index is returned.  The data is scanned
+// once.
+func (s *Sorter[T]) MaxIndex(data []T) int {
+	if len(data) == 0 {
+		return -1
+	}
+	max := 0
+	for i := 1; i < len(data); i++ {
+		if s.Less(data[max], data[i]) {
+			max = i
+		}
+	}
+	return max
+}
+
+// Chunks splits the data into runs of consecutive elements that are equal
+// according to [Sorter.Equal], such as the groups of equal keys in data that
+// is already sorted by the Sorter.  Unsorted data is split wherever adjacent
+// elements differ, so equal elements that are not adjacent end up in
+// different chunks.
+//
+// The chunks alias the data rather than copying it, so modifying an element of
+// a chunk modifies the data.  Each chunk's capacity is limited to its length,
+// so appending to a chunk copies it rather than overwriting the next one.
+// Chunks returns nil for empty data.
+func (s *Sorter[T]) Chunks(data []T) [][]T {
+	var out [][]T
+	start := 0
+	for i := 1; i <= len(data); i++ {
+		if i == len(data) || !s.Equal(data[start], data[i]) {
+			out = append(out, data[start:i:i])
+			start = i
+		}
+	}
+	return out
+}
+
+// SortBoundaries sorts the data in place according to the Sorter and returns
+// the indices at which each run of equal elements, as reported by
+// [Sorter.Equal], starts, such as to render the sorted data as a grouped
+// table:
+//
+//	starts := sorter.SortBoundaries(rows)
+//	for i, start := range starts {
+//		end := len(rows)
+//		if i+1 < len(starts) {
+//			end = starts[i+1]
+//		}
+//		renderGroup(rows[start:end])
+//	}
+//
+// The first index is always 0 for non-empty data; SortBoundaries returns nil
+// for empty data.  Like [slices.SortFunc], it does not preserve the relative
+// order of equal elements.
+func (s *Sorter[T]) SortBoundaries(data []T) []int {
+	slices.SortFunc(data, s.Less)
+	var starts []int
+	for i := range data {
+		if i == 0 || s.Less(data[i-1], data[i]) {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// Range returns the contiguous run of elements e of the data with lo <= e <=
+// hi according to the Sorter, that is, !s.Less(e, lo) && !s.Less(hi, e).  Both
+// bounds are inclusive, and elements equal to them are included.  The data
+// must already be sorted by the Sorter, which Range exploits to find the run by
+// binary search in O(log n) comparisons.
+//
+// The result aliases the data.  It is empty if no element falls within the
+// bounds, including when hi sorts before lo.
+func (s *Sorter[T]) Range(data []T, lo, hi T) []T {
+	start := sort.Search(len(data), func(i int) bool { return !s.Less(data[i], lo) })
+	end := sort.Search(len(data), func(i int) bool { return s.Less(hi, data[i]) })
+	if end < start {
+		end = start
+	}
+	return data[start:end]
+}
@@ -0,0 +1,436 @@
+package esort
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestSortStable(t *testing.T) {
+	parity := func(l, r Data) bool { return l.Int%2 < r.Int%2 }
+	for _, test := range []struct {
+		name    string
+		s       *Sorter[Data]
+		in, out []Data
+	}{
+		{
+			name: "func stable asc",
+			s: New[Data]().
+				ByFuncStable(parity, Asc).
+				ByString(func(d Data) string { return d.String }, Asc),
+			in: []Data{
+				{Int: 1, String: "d"},
+				{Int: 0, String: "c"},
+				{Int: 3, String: "b"},
+				{Int: 2, String: "a"},
+			},
+			out: []Data{
+				{Int: 0, String: "c"},
+				{Int: 2, String: "a"},
+				{Int: 1, String: "d"},
+				{Int: 3, String: "b"},
+			},
+		},
+		{
+			name: "func stable desc",
+			s: New[Data]().
+				ByFuncStable(parity, Desc).
+				ByString(func(d Data) string { return d.String }, Asc),
+			in: []Data{
+				{Int: 1, String: "d"},
+				{Int: 0, String: "c"},
+				{Int: 3, String: "b"},
+				{Int: 2, String: "a"},
+			},
+			out: []Data{
+				{Int: 1, String: "d"},
+				{Int: 3, String: "b"},
+				{Int: 0, String: "c"},
+				{Int: 2, String: "a"},
+			},
+		},
+		{
+			name: "func unstable",
+			s: New[Data]().
+				ByFunc(parity, Asc).
+				ByString(func(d Data) string { return d.String }, Asc),
+			in: []Data{
+				{Int: 1, String: "d"},
+				{Int: 0, String: "c"},
+				{Int: 3, String: "b"},
+				{Int: 2, String: "a"},
+			},
+			out: []Data{
+				{Int: 2, String: "a"},
+				{Int: 0, String: "c"},
+				{Int: 3, String: "b"},
+				{Int: 1, String: "d"},
+			},
+		},
+		{
+			name: "ties keep input order",
+			s:    New[Data]().ByInt(func(d Data) int { return d.Int }, Asc),
+			in: []Data{
+				{Int: 1, String: "a"},
+				{Int: 0, String: "b"},
+				{Int: 1, String: "c"},
+				{Int: 0, String: "d"},
+				{Int: 1, String: "e"},
+			},
+			out: []Data{
+				{Int: 0, String: "b"},
+				{Int: 0, String: "d"},
+				{Int: 1, String: "a"},
+				{Int: 1, String: "c"},
+				{Int: 1, String: "e"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := make([]Data, len(test.in))
+			copy(out, test.in)
+			test.s.SortStable(out)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("s.SortStable(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestSortChanged(t *testing.T) {
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Asc)
+	for _, test := range []struct {
+		name    string
+		in, out []Data
+		changed bool
+	}{
+		{
+			name:    "empty",
+			in:      []Data{},
+			out:     []Data{},
+			changed: false,
+		},
+		{
+			name:    "sorted",
+			in:      []Data{{Int: 0}, {Int: 1}, {Int: 1}, {Int: 2}},
+			out:     []Data{{Int: 0}, {Int: 1}, {Int: 1}, {Int: 2}},
+			changed: false,
+		},
+		{
+			name:    "unsorted",
+			in:      []Data{{Int: 0}, {Int: 2}, {Int: 1}},
+			out:     []Data{{Int: 0}, {Int: 1}, {Int: 2}},
+			changed: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := make([]Data, len(test.in))
+			copy(out, test.in)
+			if got, want := s.SortChanged(out), test.changed; got != want {
+				t.Errorf("s.SortChanged(%v) = %v, want %v", test.in, got, want)
+			}
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("after s.SortChanged(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+			}
+			if !s.IsSorted(out) {
+				t.Errorf("s.IsSorted(%v) = false, want true", out)
+			}
+		})
+	}
+}
+
+func TestRecoverExtractorPanics(t *testing.T) {
+	names := map[int]string{1: "a", 2: "b", 3: "c"}
+	name := func(d Data) string {
+		n, ok := names[d.Int]
+		if !ok {
+			panic(fmt.Sprintf("no name for %d", d.Int))
+		}
+		return n
+	}
+	s := New[Data]().ByString(name, Asc)
+
+	in := []Data{{Int: 3}, {Int: 1}, {Int: 2}}
+	out := slices.Clone(in)
+	if err := s.RecoverExtractorPanics().SortE(out); err != nil {
+		t.Errorf("s.RecoverExtractorPanics().SortE(%v) = %v, want nil", in, err)
+	}
+	if want := []Data{{Int: 1}, {Int: 2}, {Int: 3}}; !cmp.Equal(want, out) {
+		t.Errorf("after s.RecoverExtractorPanics().SortE(%v) = %v, want %v", in, out, want)
+	}
+
+	in = []Data{{Int: 3}, {Int: 4}, {Int: 1}}
+	err := s.RecoverExtractorPanics().SortE(slices.Clone(in))
+	if got, want := fmt.Sprint(err), "esort: extractor panicked: no name for 4"; got != want {
+		t.Errorf("s.RecoverExtractorPanics().SortE(%v) = %v, want %v", in, got, want)
+	}
+
+	// The option survives deriving further Sorters.
+	derived := s.RecoverExtractorPanics().ByInt(func(d Data) int { return d.Int }, Asc).Label("id")
+	if err := derived.SortE(slices.Clone(in)); err == nil {
+		t.Errorf("derived.SortE(%v) = nil, want error", in)
+	}
+
+	func() {
+		defer func() {
+			if got, want := fmt.Sprint(recover()), "esort: extractor panicked: no name for 4"; got != want {
+				t.Errorf("s.RecoverExtractorPanics().Sort(%v) panic = %v, want %v", in, got, want)
+			}
+		}()
+		s.RecoverExtractorPanics().Sort(slices.Clone(in))
+	}()
+
+	func() {
+		defer func() {
+			if got, want := fmt.Sprint(recover()), "no name for 4"; got != want {
+				t.Errorf("s.SortE(%v) panic = %v, want %v", in, got, want)
+			}
+		}()
+		s.SortE(slices.Clone(in))
+	}()
+}
+
+func TestRecoverExtractorPanicsError(t *testing.T) {
+	errBad := errors.New("bad")
+	s := New[Data]().ByInt(func(Data) int { panic(errBad) }, Asc).RecoverExtractorPanics()
+	if err := s.SortE([]Data{{}, {}}); !errors.Is(err, errBad) {
+		t.Errorf("s.SortE(…) = %v, want error wrapping %v", err, errBad)
+	}
+	if err := New[Data]().RecoverExtractorPanics().SortE([]Data{{}, {}}); err != errNoProgram {
+		t.Errorf("New().RecoverExtractorPanics().SortE(…) = %v, want %v", err, errNoProgram)
+	}
+}
+
+func TestSortStableDesc(t *testing.T) {
+	// Every element ties with nine others under the Desc key, and Uint records
+	// the input order.
+	in := make([]Data, 100)
+	for i := range in {
+		in[i] = Data{Int: i * 7 % 10, Uint: uint(i)}
+	}
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Desc)
+	for _, test := range []struct {
+		name string
+		sort func([]Data)
+	}{
+		{"SortStable", s.SortStable},
+		{"slices.SortStableFunc", func(data []Data) { slices.SortStableFunc(data, s.Less) }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			test.sort(out)
+			for i := 1; i < len(out); i++ {
+				l, r := out[i-1], out[i]
+				switch {
+				case l.Int < r.Int:
+					t.Fatalf("out[%d].Int = %d before out[%d].Int = %d, want descending", i-1, l.Int, i, r.Int)
+				case l.Int == r.Int && l.Uint > r.Uint:
+					t.Fatalf("out[%d] = %v before out[%d] = %v, want input order among ties", i-1, l, i, r)
+				}
+			}
+		})
+	}
+}
+
+func TestSortWith(t *testing.T) {
+	s := New[int]().ByInt(func(v int) int { return v }, Desc)
+	data := []int{1, 3, 2, 3, 1}
+	tags := []string{"a", "b", "c", "d", "e"}
+	scores := []float64{0.1, 0.2, 0.3, 0.4, 0.5}
+	if err := s.SortWith(data, Aux(tags), Aux(scores)); err != nil {
+		t.Fatalf("s.SortWith(…) = %v, want nil", err)
+	}
+	if diff := cmp.Diff([]int{3, 3, 2, 1, 1}, data); diff != "" {
+		t.Errorf("data after s.SortWith(…) = %v\n\ndiff (-want, +got):\n%v", data, diff)
+	}
+	if diff := cmp.Diff([]string{"b", "d", "c", "a", "e"}, tags); diff != "" {
+		t.Errorf("tags after s.SortWith(…) = %v\n\ndiff (-want, +got):\n%v", tags, diff)
+	}
+	if diff := cmp.Diff([]float64{0.2, 0.4, 0.3, 0.1, 0.5}, scores); diff != "" {
+		t.Errorf("scores after s.SortWith(…) = %v\n\ndiff (-want, +got):\n%v", scores, diff)
+	}
+}
+
+func TestSortWithLengthMismatch(t *testing.T) {
+	s := New[int]().ByInt(func(v int) int { return v }, Asc)
+	data := []int{2, 1}
+	tags := []string{"a", "b", "c"}
+	if err := s.SortWith(data, Aux([]string{"x", "y"}), Aux(tags)); err == nil {
+		t.Error("s.SortWith(…) with mismatched lengths = nil, want error")
+	}
+	if diff := cmp.Diff([]int{2, 1}, data); diff != "" {
+		t.Errorf("data after failed s.SortWith(…) = %v, want unmodified\n\ndiff (-want, +got):\n%v", data, diff)
+	}
+}
+
+func TestMinMaxIndex(t *testing.T) {
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Desc)
+	for _, test := range []struct {
+		name     string
+		in       []Data
+		min, max int
+	}{
+		{"nil", nil, -1, -1},
+		{"single", []Data{{Int: 5}}, 0, 0},
+		{"duplicates", []Data{{Int: 1}, {Int: 3}, {Int: 0}, {Int: 3}, {Int: 0}}, 1, 2},
+		{"all equal", []Data{{Int: 2}, {Int: 2}, {Int: 2}}, 0, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := s.MinIndex(test.in); got != test.min {
+				t.Errorf("s.MinIndex(%v) = %d, want %d", test.in, got, test.min)
+			}
+			if got := s.MaxIndex(test.in); got != test.max {
+				t.Errorf("s.MaxIndex(%v) = %d, want %d", test.in, got, test.max)
+			}
+		})
+	}
+}
+
+func TestChunks(t *testing.T) {
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Asc)
+	for _, test := range []struct {
+		name string
+		in   []Data
+		out  [][]Data
+	}{
+		{"empty", nil, nil},
+		{
+			name: "several groups",
+			in:   []Data{{Int: 1, Uint: 0}, {Int: 1, Uint: 1}, {Int: 2, Uint: 2}, {Int: 3, Uint: 3}, {Int: 3, Uint: 4}},
+			out: [][]Data{
+				{{Int: 1, Uint: 0}, {Int: 1, Uint: 1}},
+				{{Int: 2, Uint: 2}},
+				{{Int: 3, Uint: 3}, {Int: 3, Uint: 4}},
+			},
+		},
+		{
+			name: "all equal",
+			in:   []Data{{Int: 7, Uint: 0}, {Int: 7, Uint: 1}, {Int: 7, Uint: 2}},
+			out:  [][]Data{{{Int: 7, Uint: 0}, {Int: 7, Uint: 1}, {Int: 7, Uint: 2}}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := s.Chunks(test.in)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("s.Chunks(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestChunksAliasing(t *testing.T) {
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Asc)
+	data := []Data{{Int: 1}, {Int: 2}, {Int: 2}}
+	chunks := s.Chunks(data)
+	chunks[1][0].Uint = 9
+	if got, want := data[1].Uint, uint(9); got != want {
+		t.Errorf("data[1].Uint after modifying chunks[1][0] = %d, want %d", got, want)
+	}
+	_ = append(chunks[0], Data{Int: 5})
+	if got, want := data[1].Int, 2; got != want {
+		t.Errorf("data[1].Int after appending to chunks[0] = %d, want %d", got, want)
+	}
+}
+
+func TestRange(t *testing.T) {
+	s := New[int]().ByInt(func(v int) int { return v }, Asc)
+	data := []int{1, 3, 3, 5, 7, 7, 9}
+	for _, test := range []struct {
+		name   string
+		lo, hi int
+		out    []int
+	}{
+		{"middle", 3, 7, []int{3, 3, 5, 7, 7}},
+		{"between elements", 4, 6, []int{5}},
+		{"low edge", 0, 1, []int{1}},
+		{"high edge", 9, 10, []int{9}},
+		{"all", -10, 10, []int{1, 3, 3, 5, 7, 7, 9}},
+		{"empty gap", 4, 4, []int{}},
+		{"below", -5, 0, []int{}},
+		{"above", 10, 20, []int{}},
+		{"inverted", 7, 3, []int{}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := s.Range(data, test.lo, test.hi)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("s.Range(%v, %d, %d) = %v, want %v\n\ndiff (-want, +got):\n%v", data, test.lo, test.hi, out, test.out, diff)
+			}
+		})
+	}
+
+	desc := New[int]().ByInt(func(v int) int { return v }, Desc)
+	data = []int{9, 7, 5, 3, 1}
+	if got, want := desc.Range(data, 7, 3), []int{7, 5, 3}; !cmp.Equal(want, got) {
+		t.Errorf("desc.Range(%v, 7, 3) = %v, want %v", data, got, want)
+	}
+}
+
+func TestIsSortedPrefix(t *testing.T) {
+	s := New[int]().ByInt(func(v int) int { return v }, Asc)
+	data := []int{1, 2, 3, 4, 9, 0, 5}
+	for _, test := range []struct {
+		k    int
+		want bool
+	}{
+		{-1, true},
+		{0, true},
+		{3, true},
+		{5, true},
+		{6, false},
+		{7, false},
+		{100, false},
+	} {
+		if got := s.IsSortedPrefix(data, test.k); got != test.want {
+			t.Errorf("s.IsSortedPrefix(%v, %d) = %v, want %v", data, test.k, got, test.want)
+		}
+	}
+}
+
+func TestSortReverseStable(t *testing.T) {
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Asc)
+	var in []Data
+	for i := 0; i < 200; i++ {
+		in = append(in, Data{Int: i * 7 % 5, Uint: uint(i)})
+	}
+	want := slices.Clone(in)
+	slices.SortStableFunc(want, func(l, r Data) bool { return l.Int > r.Int })
+	out := slices.Clone(in)
+	s.SortReverseStable(out)
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("s.SortReverseStable(…) diff (-want, +got):\n%v", diff)
+	}
+	for i := 1; i < len(out); i++ {
+		if out[i-1].Int == out[i].Int && out[i-1].Uint > out[i].Uint {
+			t.Fatalf("s.SortReverseStable(…) placed %v before %v, want original order among ties", out[i-1], out[i])
+		}
+	}
+}
+
+func TestSortBoundaries(t *testing.T) {
+	s := New[Data]().ByInt(func(d Data) int { return d.Int }, Desc)
+	for _, test := range []struct {
+		name string
+		in   []Data
+		want []int
+	}{
+		{"nil", nil, nil},
+		{"single", []Data{{Int: 1}}, []int{0}},
+		{"one group", []Data{{Int: 2}, {Int: 2}, {Int: 2}}, []int{0}},
+		{"groups", []Data{{Int: 1}, {Int: 3}, {Int: 0}, {Int: 3}, {Int: 1}, {Int: 3}}, []int{0, 3, 5}},
+		{"distinct", []Data{{Int: 1}, {Int: 2}, {Int: 3}}, []int{0, 1, 2}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data := slices.Clone(test.in)
+			got := s.SortBoundaries(data)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("s.SortBoundaries(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, got, test.want, diff)
+			}
+			if !s.IsSorted(data) {
+				t.Errorf("s.SortBoundaries(%v) left data unsorted: %v", test.in, data)
+			}
+		})
+	}
+}
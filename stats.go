@@ -0,0 +1,28 @@
+package esort
+
+// Stats summarizes the composition of a Sorter's program, such as for an
+// administrative dashboard alongside [Sorter.MarshalText].
+type Stats struct {
+	// NumInstructions is the number of instructions.
+	NumInstructions int
+	// NumAsc and NumDesc are the numbers of instructions sorting in
+	// ascending and descending order.
+	NumAsc, NumDesc int
+	// ByKind counts the instructions of each [Kind].  Kinds without
+	// instructions are absent.
+	ByKind map[Kind]int
+}
+
+// Stats returns a summary of the Sorter's program.
+func (s *Sorter[T]) Stats() Stats {
+	st := Stats{NumInstructions: len(s.prog), ByKind: make(map[Kind]int)}
+	for _, o := range s.prog {
+		if o.Dir == Desc {
+			st.NumDesc++
+		} else {
+			st.NumAsc++
+		}
+		st.ByKind[o.Kind]++
+	}
+	return st
+}
@@ -0,0 +1,45 @@
+package esort
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStats(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		s    *Sorter[Data]
+		want Stats
+	}{
+		{"empty", New[Data](), Stats{ByKind: map[Kind]int{}}},
+		{
+			name: "mixed",
+			s: New[Data]().
+				ByInt(func(d Data) int { return d.Int }, Desc).
+				ByString(func(d Data) string { return d.String }, Asc).
+				ByStringFold(func(d Data) string { return d.String }, Desc).
+				ByFunc(func(l, r Data) bool { return l.Uint < r.Uint }, Asc),
+			want: Stats{
+				NumInstructions: 4,
+				NumAsc:          2,
+				NumDesc:         2,
+				ByKind:          map[Kind]int{KindInteger: 1, KindString: 2, KindFunc: 1},
+			},
+		},
+		{
+			name: "reversed",
+			s: New[Data]().
+				ByInt(func(d Data) int { return d.Int }, Asc).
+				ByUint(func(d Data) uint { return d.Uint }, Asc).
+				ReverseKind(KindInteger),
+			want: Stats{NumInstructions: 2, NumDesc: 2, ByKind: map[Kind]int{KindInteger: 2}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.s.Stats()); diff != "" {
+				t.Errorf("s.Stats() diff (-want, +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,657 @@
+package esort
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// foldRune returns the canonical representative of r under Unicode simple
+// case folding: the smallest rune in the orbit of [unicode.SimpleFold].
+// Mapping every rune of an orbit to the same representative keeps comparisons
+// of folded strings transitive, which lowering or uppercasing alone does not
+// guarantee (e.g., 'K', 'k', and KELVIN SIGN U+212A).
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		return r
+	}
+	m := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < m {
+			m = f
+		}
+	}
+	return m
+}
+
+// compareFold compares l and r rune by rune under Unicode simple case
+// folding.  A string that is a folded prefix of the other sorts first.
+func compareFold(l, r string) int {
+	for l != "" && r != "" {
+		lr, ln := utf8.DecodeRuneInString(l)
+		rr, rn := utf8.DecodeRuneInString(r)
+		if lr != rr {
+			if lf, rf := foldRune(lr), foldRune(rr); lf != rf {
+				if lf < rf {
+					return -1
+				}
+				return 1
+			}
+		}
+		l, r = l[ln:], r[rn:]
+	}
+	switch {
+	case l == "" && r == "":
+		return 0
+	case l == "":
+		return -1
+	}
+	return 1
+}
+
+// ByStringFold sorts the data by a given string value case-insensitively
+// under Unicode simple case folding, such that "Go", "GO", and "go" tie.  The
+// strings are folded rune by rune during comparison and no memory is
+// allocated.
+//
+// Simple case folding maps runes one to one, so it does not equate
+// multi-rune foldings like "ß" and "ss".
+func (s *Sorter[T]) ByStringFold(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareFold(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// isDigit reports whether b is an ASCII decimal digit.
+func isDigit(b byte) bool { return '0' <= b && b <= '9' }
+
+// compareDigits compares two runs of ASCII decimal digits numerically without
+// parsing them, so runs of any length are supported.  Leading zeros are
+// insignificant: "007" and "7" are equal.
+func compareDigits(l, r string) int {
+	l, r = strings.TrimLeft(l, "0"), strings.TrimLeft(r, "0")
+	switch {
+	case len(l) < len(r):
+		return -1
+	case len(r) < len(l):
+		return 1
+	}
+	return strings.Compare(l, r)
+}
+
+// splitNumericSuffix splits s into the prefix before its trailing run of ASCII
+// decimal digits and the run itself, which is empty if s does not end in a
+// digit.
+func splitNumericSuffix(s string) (prefix, digits string) {
+	i := len(s)
+	for i > 0 && isDigit(s[i-1]) {
+		i--
+	}
+	return s[:i], s[i:]
+}
+
+// ByNumericSuffix sorts the data by a given string value that ends in a
+// number, such as "node-1", "node-2", …, "node-10".  The strings are ordered
+// lexically by the prefix before the trailing run of decimal digits and then
+// numerically by the run, so "node-2" sorts before "node-10".  A string
+// without a trailing number sorts before those with one and an otherwise
+// equal prefix: "node" sorts before "node1".  Leading zeros are insignificant,
+// so "node-07" and "node-7" tie.
+func (s *Sorter[T]) ByNumericSuffix(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		lp, ln := splitNumericSuffix(f(l))
+		rp, rn := splitNumericSuffix(f(r))
+		if c := strings.Compare(lp, rp); c != 0 {
+			return c
+		}
+		switch {
+		case ln == "" && rn != "":
+			return -1
+		case ln != "" && rn == "":
+			return 1
+		}
+		return compareDigits(ln, rn)
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// ByTrailingNumberFirst sorts the data by a given string value like
+// [Sorter.ByNumericSuffix] but with the keys swapped: the strings are ordered
+// numerically by their trailing run of decimal digits and then lexically by the
+// prefix before it.  This groups the strings by number, such as "a-1", "b-1",
+// "a-2", "b-2" for replicas named after the shard they serve.  A string without
+// a trailing number sorts before those with one in ascending order, and such
+// strings are ordered lexically among themselves.  Leading zeros are
+// insignificant, so "a-07" and "a-7" tie.
+func (s *Sorter[T]) ByTrailingNumberFirst(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		lp, ln := splitNumericSuffix(f(l))
+		rp, rn := splitNumericSuffix(f(r))
+		switch {
+		case ln == "" && rn != "":
+			return -1
+		case ln != "" && rn == "":
+			return 1
+		}
+		if c := compareDigits(ln, rn); c != 0 {
+			return c
+		}
+		return strings.Compare(lp, rp)
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// isDigits reports whether s is a non-empty run of ASCII decimal digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// compareDottedVersion compares dotted versions segment by segment.
+func compareDottedVersion(l, r string) int {
+	for l != "" || r != "" {
+		ls, rs := "0", "0"
+		if l != "" {
+			ls, l, _ = strings.Cut(l, ".")
+		}
+		if r != "" {
+			rs, r, _ = strings.Cut(r, ".")
+		}
+		ln, rn := isDigits(ls), isDigits(rs)
+		var c int
+		switch {
+		case ln && rn:
+			c = compareDigits(ls, rs)
+		case ln != rn:
+			if ln {
+				return -1
+			}
+			return 1
+		default:
+			c = strings.Compare(ls, rs)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// ByDottedVersion sorts the data by a given version string consisting of an
+// arbitrary number of dot-separated integers, such as "2024.3.15.7".  The
+// versions are compared segment by segment numerically, so "1.10" sorts after
+// "1.9", and leading zeros are insignificant.  Missing trailing segments are
+// treated as zero, so "1.2" and "1.2.0" tie.
+//
+// Segments that are not decimal integers sort after numeric segments at the
+// same position and lexically among themselves, so "1.x" sorts after "1.9".
+// Use a semantic versioning library for versions with prerelease or build
+// metadata.
+func (s *Sorter[T]) ByDottedVersion(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareDottedVersion(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// digitPrefix returns the leading run of ASCII decimal digits of s.
+func digitPrefix(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i]
+}
+
+// compareNaturalFold compares l and r in natural order under Unicode simple
+// case folding: runs of decimal digits compare numerically as a unit, and all
+// other runes compare as in compareFold.  Every digit run sorts relative to a
+// non-digit rune by the rune's code point, which keeps the order transitive.
+func compareNaturalFold(l, r string) int {
+	for l != "" && r != "" {
+		if isDigit(l[0]) && isDigit(r[0]) {
+			ld, rd := digitPrefix(l), digitPrefix(r)
+			if c := compareDigits(ld, rd); c != 0 {
+				return c
+			}
+			l, r = l[len(ld):], r[len(rd):]
+			continue
+		}
+		lr, ln := utf8.DecodeRuneInString(l)
+		rr, rn := utf8.DecodeRuneInString(r)
+		if lr != rr {
+			if lf, rf := foldRune(lr), foldRune(rr); lf != rf {
+				if lf < rf {
+					return -1
+				}
+				return 1
+			}
+		}
+		l, r = l[ln:], r[rn:]
+	}
+	switch {
+	case l == "" && r == "":
+		return 0
+	case l == "":
+		return -1
+	}
+	return 1
+}
+
+// ByProductName sorts the data by a given string value in natural order
+// case-insensitively, as suits product listings: runs of decimal digits
+// compare numerically, so "iPhone 2" sorts before "iPhone 11", and letters
+// compare under Unicode simple case folding as in [Sorter.ByStringFold], so
+// "IPHONE 2" and "iphone 2" tie.  Leading zeros are insignificant.
+//
+// Combining both rules in a single instruction avoids the precedence and
+// direction mistakes that chaining separate instructions invites.
+func (s *Sorter[T]) ByProductName(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareNaturalFold(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// mysqlLatinExtA maps U+0100 through U+017F (Latin Extended-A) to the base
+// letters they weigh as under MySQL's utf8_general_ci.  A '.' marks a letter
+// that is only folded to upper case.
+const mysqlLatinExtA = "AAAAAACCCCCCCCDDDDEEEEEEEEEEGGGGGGGGHHHHIIIIIIIIII..JJKK.LLLLLLLLLLNNNNNN...OOOOOO..RRRRRRSSSSSSSSTTTTTTUUUUUUUUUUUUWWYYYZZZZZZS"
+
+// mysqlWeight returns the weight of r under an approximation of MySQL's
+// utf8_general_ci: letters of the Latin-1 Supplement and Latin Extended-A
+// blocks weigh as their unaccented base letter, and all other runes weigh as
+// their upper case.
+func mysqlWeight(r rune) rune {
+	switch {
+	case r < utf8.RuneSelf:
+		if 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		return r
+	case 0xC0 <= r && r <= 0xFF:
+		switch r {
+		case 0xC6, 0xD0, 0xD7, 0xD8, 0xDE, 0xE6, 0xF0, 0xF7, 0xF8, 0xFE:
+			// Æ, Ð, ×, Ø, and Þ have weights of their own.
+		case 0xDF: // ß
+			return 'S'
+		default:
+			return rune("AAAAAA.CEEEEIIII.NOOOOO..UUUUY.Y"[(r-0xC0)%0x20])
+		}
+	case 0x100 <= r && r <= 0x17F:
+		if b := mysqlLatinExtA[r-0x100]; b != '.' {
+			return rune(b)
+		}
+	}
+	return unicode.ToUpper(r)
+}
+
+// compareMySQLCI compares l and r by their utf8_general_ci weights rune by
+// rune, ignoring trailing spaces as MySQL's PAD SPACE collations do.
+func compareMySQLCI(l, r string) int {
+	l, r = strings.TrimRight(l, " "), strings.TrimRight(r, " ")
+	for l != "" && r != "" {
+		lr, ln := utf8.DecodeRuneInString(l)
+		rr, rn := utf8.DecodeRuneInString(r)
+		if lr != rr {
+			if lw, rw := mysqlWeight(lr), mysqlWeight(rr); lw != rw {
+				if lw < rw {
+					return -1
+				}
+				return 1
+			}
+		}
+		l, r = l[ln:], r[rn:]
+	}
+	switch {
+	case l == "" && r == "":
+		return 0
+	case l == "":
+		return -1
+	}
+	return 1
+}
+
+// ByMySQLCI sorts the data by a given string value as MySQL's utf8_general_ci
+// collation does, so that sorting in the application agrees with ORDER BY in
+// the database.  Strings compare case-insensitively and accent-insensitively
+// rune by rune, such that "resume", "Résumé", and "RESUME" are equivalent, and
+// "ß" weighs as "s".  Trailing spaces are insignificant.  Equivalent strings
+// are then ordered by code point, so the order is total and deterministic,
+// whereas MySQL leaves them in an unspecified order.
+//
+// The collation is approximated: accents are only removed from letters of the
+// Latin-1 Supplement and Latin Extended-A blocks, and all other runes compare
+// by their upper case.
+func (s *Sorter[T]) ByMySQLCI(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		lv, rv := f(l), f(r)
+		if c := compareMySQLCI(lv, rv); c != 0 {
+			return c
+		}
+		return strings.Compare(lv, rv)
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// splitIntegerString splits a decimal integer string with an optional sign
+// into whether it is negative and its digits without leading zeros.  ok is
+// false if s is not a decimal integer.  Zero is never negative.
+func splitIntegerString(s string) (neg bool, digits string, ok bool) {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg, s = s[0] == '-', s[1:]
+	}
+	if !isDigits(s) {
+		return false, "", false
+	}
+	digits = strings.TrimLeft(s, "0")
+	return neg && digits != "", digits, true
+}
+
+// compareIntegerString compares two decimal integer strings numerically
+// without parsing them.  Strings that are not decimal integers sort after all
+// integers and lexically among themselves.
+func compareIntegerString(l, r string) int {
+	lneg, ld, lok := splitIntegerString(l)
+	rneg, rd, rok := splitIntegerString(r)
+	switch {
+	case !lok && !rok:
+		return strings.Compare(l, r)
+	case !lok:
+		return 1
+	case !rok:
+		return -1
+	case lneg && !rneg:
+		return -1
+	case !lneg && rneg:
+		return 1
+	}
+	c := compareDigits(ld, rd)
+	if lneg {
+		return -c
+	}
+	return c
+}
+
+// ByIntegerString sorts the data by a given string value holding a decimal
+// integer with an optional sign, such as an identifier, numerically without
+// parsing it: the digits are compared by length and then lexically, so "100"
+// sorts after "99" and "-5" before "3".  Integers of any length are supported
+// and no memory is allocated.  Leading zeros are insignificant, so "007" and
+// "7" tie, as do "-0" and "0".
+//
+// Strings that are not decimal integers sort after all integers in ascending
+// order and lexically among themselves.
+func (s *Sorter[T]) ByIntegerString(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareIntegerString(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// utf16Lead returns the first UTF-16 code unit that encodes r.
+func utf16Lead(r rune) rune {
+	if r < 0x10000 {
+		return r
+	}
+	return 0xD800 + (r-0x10000)>>10
+}
+
+// compareUTF16 compares l and r by the UTF-16 code units encoding them.
+func compareUTF16(l, r string) int {
+	for l != "" && r != "" {
+		lr, ln := utf8.DecodeRuneInString(l)
+		rr, rn := utf8.DecodeRuneInString(r)
+		if lr != rr {
+			lu, ru := utf16Lead(lr), utf16Lead(rr)
+			if lu == ru {
+				// Both runes are surrogate pairs with the same high
+				// surrogate, whose low surrogates order as the runes do.
+				lu, ru = lr, rr
+			}
+			if lu < ru {
+				return -1
+			}
+			return 1
+		}
+		l, r = l[ln:], r[rn:]
+	}
+	switch {
+	case l == "" && r == "":
+		return 0
+	case l == "":
+		return -1
+	}
+	return 1
+}
+
+// ByStringUTF16 sorts the data by a given string value in the order of its
+// UTF-16 code units, which is how JavaScript's Array.prototype.sort and Java's
+// String.compareTo order strings.  It differs from the code point order of
+// [Sorter.ByString] only for runes outside the Basic Multilingual Plane, such
+// as most emoji: their surrogate pairs start with a code unit in the range
+// U+D800 to U+DBFF, so they sort before runes from U+E000 to U+FFFF, such as
+// "～" (FULLWIDTH TILDE), rather than after them.  The strings are not
+// converted and no memory is allocated.  Invalid UTF-8 is compared as
+// U+FFFD.
+func (s *Sorter[T]) ByStringUTF16(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareUTF16(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// runePrefix returns the first n runes of s, or all of s if it is shorter.
+func runePrefix(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// ByPrefixGroup sorts the data by the first n runes of a given string value,
+// which clusters strings sharing that prefix, such as log messages from the
+// same source.  Strings with fewer than n runes use their whole content.
+// Strings within a group tie, so later instructions order them; chain
+// [Sorter.ByString] last for a full tie-break:
+//
+//	sorter := esort.New[Entry]().
+//		ByPrefixGroup(func(e Entry) string { return e.Message }, 5, esort.Asc).
+//		ByTime(func(e Entry) time.Time { return e.Time }, esort.Desc).
+//		ByString(func(e Entry) string { return e.Message }, esort.Asc)
+//
+// The prefixes are compared in place without allocating.
+func (s *Sorter[T]) ByPrefixGroup(f func(T) string, n int, d Dir) *Sorter[T] {
+	key := func(v T) string { return runePrefix(f(v), n) }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d, Kind: KindString})
+}
+
+// ByRegexpGroup sorts the data by the text that capture group group of re
+// matches in a given string value, such as the sequence number of identifiers
+// like "ORDER-2023-0042":
+//
+//	re := regexp.MustCompile(`^ORDER-\d+-(\d+)$`)
+//	sorter := esort.New[Order]().ByRegexpGroup(func(o Order) string { return o.ID }, re, 1, esort.Asc)
+//
+// The captured texts are compared like [Sorter.ByIntegerString]: decimal
+// integers numerically, and other texts after them and lexically.  A group
+// that does not participate in the match captures the empty string.  Strings
+// that re does not match sort after all matching ones regardless of direction
+// and tie with one another, so later instructions order them.  Group 0 is the
+// whole match.
+//
+// re is matched twice per comparison, so it should be compiled once and
+// reused.  ByRegexpGroup panics if group is not a group of re.
+func (s *Sorter[T]) ByRegexpGroup(f func(T) string, re *regexp.Regexp, group int, d Dir) *Sorter[T] {
+	if group < 0 || group > re.NumSubexp() {
+		panic(fmt.Errorf("esort: group %d out of range for %d groups of %v", group, re.NumSubexp(), re))
+	}
+	capture := func(v T) (string, bool) {
+		m := re.FindStringSubmatch(f(v))
+		if m == nil {
+			return "", false
+		}
+		return m[group], true
+	}
+	fn := func(l, r T, d Dir) int {
+		lm, lok := capture(l)
+		rm, rok := capture(r)
+		if less, ok := nullLess(!lok, !rok, NullsLast, d); ok {
+			switch {
+			case less:
+				return -1
+			case lok == rok:
+				return 0
+			}
+			return 1
+		}
+		return compareIntegerString(lm, rm)
+	}
+	return s.addInst(inst[T]{CmpDir: fn, Dir: d, Kind: KindString})
+}
+
+// ByLengthBand sorts the data by the band that the number of runes of a given
+// string value falls into, such as short, medium, and long tags in a tag
+// cloud.  edges lists the lengths separating the bands in ascending order:
+// each edge is the shortest length of the next band, so a length below
+// edges[0] falls into band 0, a length in [edges[i-1], edges[i]) into band i,
+// and a length at or above the last edge into band len(edges).  Strings in the
+// same band tie, so later instructions order them:
+//
+//	sorter := esort.New[Tag]().
+//		ByLengthBand(func(t Tag) string { return t.Name }, []int{5, 10}, esort.Asc).
+//		ByString(func(t Tag) string { return t.Name }, esort.Asc)
+//
+// ByLengthBand panics if edges is not sorted in ascending order.  edges is
+// copied, so later modifications to it have no effect on the Sorter.
+func (s *Sorter[T]) ByLengthBand(f func(T) string, edges []int, d Dir) *Sorter[T] {
+	if !sort.IntsAreSorted(edges) {
+		panic(fmt.Errorf("esort: edges %v are not sorted in ascending order", edges))
+	}
+	edges = append([]int(nil), edges...)
+	key := func(v T) int { return bandIndex(edges, utf8.RuneCountInString(f(v))) }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d})
+}
+
+// ByStringPrefixAware sorts the data by a given string value lexically byte by
+// byte like [Sorter.ByString], except that shortFirst decides whether a string
+// sorts before or after the strings it is a prefix of, such as "app" relative
+// to "apple".  With shortFirst, the order is that of ByString; without it, a
+// prefix sorts after all of its extensions in ascending order:
+//
+//	apple, application, app
+//
+// [Desc] reverses the whole order, including the placement of prefixes.
+func (s *Sorter[T]) ByStringPrefixAware(f func(T) string, shortFirst bool, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		lv, rv := f(l), f(r)
+		n := len(lv)
+		if len(rv) < n {
+			n = len(rv)
+		}
+		if c := strings.Compare(lv[:n], rv[:n]); c != 0 {
+			return c
+		}
+		switch {
+		case len(lv) == len(rv):
+			return 0
+		case (len(lv) < len(rv)) == shortFirst:
+			return -1
+		}
+		return 1
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
+
+// base36Digit returns the value of the base-36 digit b, or -1 if b is not one.
+// Letters are case-insensitive.
+func base36Digit(b byte) int {
+	switch {
+	case '0' <= b && b <= '9':
+		return int(b - '0')
+	case 'a' <= b && b <= 'z':
+		return int(b-'a') + 10
+	case 'A' <= b && b <= 'Z':
+		return int(b-'A') + 10
+	}
+	return -1
+}
+
+// trimBase36 returns s without leading zeros.  ok is false if s is empty or
+// holds a byte that is not a base-36 digit.
+func trimBase36(s string) (digits string, ok bool) {
+	if s == "" {
+		return "", false
+	}
+	for i := 0; i < len(s); i++ {
+		if base36Digit(s[i]) < 0 {
+			return "", false
+		}
+	}
+	return strings.TrimLeft(s, "0"), true
+}
+
+// compareBase36 compares two base-36 strings numerically without parsing them.
+// Strings that are not base-36 numbers sort after all numbers and lexically
+// among themselves.
+func compareBase36(l, r string) int {
+	ld, lok := trimBase36(l)
+	rd, rok := trimBase36(r)
+	switch {
+	case !lok && !rok:
+		return strings.Compare(l, r)
+	case !lok:
+		return 1
+	case !rok:
+		return -1
+	case len(ld) != len(rd):
+		if len(ld) < len(rd) {
+			return -1
+		}
+		return 1
+	}
+	for i := 0; i < len(ld); i++ {
+		if lv, rv := base36Digit(ld[i]), base36Digit(rd[i]); lv != rv {
+			if lv < rv {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// ByBase36 sorts the data by the numeric value of a given base-36 string, such
+// as a short code like "A1B2", rather than lexically: "Z" (35) sorts before
+// "10" (36), and "9" before "A".  Letters are case-insensitive and leading
+// zeros are insignificant, so "0a1b2" and "A1B2" tie.  Like
+// [Sorter.ByIntegerString], the codes are compared digit by digit without
+// parsing them, so codes of any length are supported and no memory is
+// allocated.
+//
+// Invalid codes, which are empty or contain characters other than digits and
+// ASCII letters, sort after all valid codes in ascending order and lexically
+// among themselves.
+func (s *Sorter[T]) ByBase36(f func(T) string, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareBase36(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindString})
+}
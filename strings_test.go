@@ -0,0 +1,582 @@
+package esort
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestByStringFold(t *testing.T) {
+	str := func(d Data) string { return d.String }
+	for _, test := range []struct {
+		name    string
+		s       *Sorter[Data]
+		in, out []Data
+	}{
+		{
+			name: "asc",
+			s:    New[Data]().ByStringFold(str, Asc),
+			in:   []Data{{String: "cherry"}, {String: "Banana"}, {String: "apple"}, {String: "APP"}},
+			out:  []Data{{String: "APP"}, {String: "apple"}, {String: "Banana"}, {String: "cherry"}},
+		},
+		{
+			name: "desc",
+			s:    New[Data]().ByStringFold(str, Desc),
+			in:   []Data{{String: "apple"}, {String: "APP"}, {String: "Banana"}, {String: "cherry"}},
+			out:  []Data{{String: "cherry"}, {String: "Banana"}, {String: "apple"}, {String: "APP"}},
+		},
+		{
+			name: "unicode ties",
+			s:    New[Data]().ByStringFold(str, Asc).ByInt(func(d Data) int { return d.Int }, Asc),
+			in: []Data{
+				{String: "école", Int: 1},
+				{String: "Kelvin", Int: 1},
+				{String: "ÉCOLE", Int: 0},
+				{String: "kelvin", Int: 0},
+				{String: "KELVIN", Int: 2},
+				{String: "Σίσυφος", Int: 0},
+				{String: "ΣΊΣΥΦΟΣ", Int: 1},
+			},
+			out: []Data{
+				{String: "kelvin", Int: 0},
+				{String: "Kelvin", Int: 1},
+				{String: "KELVIN", Int: 2},
+				{String: "ÉCOLE", Int: 0},
+				{String: "école", Int: 1},
+				{String: "Σίσυφος", Int: 0},
+				{String: "ΣΊΣΥΦΟΣ", Int: 1},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("normal", func(t *testing.T) {
+				out := slices.Clone(test.in)
+				slices.SortFunc(out, test.s.Less)
+				if diff := cmp.Diff(test.out, out); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+				}
+			})
+			t.Run("inverse", func(t *testing.T) {
+				out := slices.Clone(test.in)
+				reverse(out)
+				slices.SortFunc(out, test.s.Less)
+				if diff := cmp.Diff(test.out, out); diff != "" {
+					t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", test.in, out, test.out, diff)
+				}
+			})
+		})
+	}
+}
+
+var foldBenchData = []string{
+	"Zulu", "alpha", "Écluse", "BRAVO", "charlie", "Delta", "ÉCOLE", "echo",
+	"Foxtrot", "golf", "HOTEL", "india", "Juliett", "kilo", "LIMA", "mike",
+}
+
+func BenchmarkByStringFold(b *testing.B) {
+	id := func(s string) string { return s }
+	for _, bench := range []struct {
+		name string
+		s    *Sorter[string]
+	}{
+		{"fold", New[string]().ByStringFold(id, Asc)},
+		{"lower", New[string]().ByFunc(func(l, r string) bool {
+			return strings.ToLower(l) < strings.ToLower(r)
+		}, Asc)},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			data := make([]string, len(foldBenchData))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(data, foldBenchData)
+				slices.SortFunc(data, bench.s.Less)
+			}
+		})
+	}
+}
+
+func TestByNumericSuffix(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"node-10", "node-2", "node", "node-1", "worker-3", "node-02", "node9", "node-", "a-100"}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"a-100", "node", "node9", "node-", "node-1", "node-2", "node-02", "node-10", "worker-3"}},
+		{"desc", Desc, []string{"worker-3", "node-10", "node-2", "node-02", "node-1", "node-", "node9", "node", "a-100"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByNumericSuffix(id, test.d)
+			out := slices.Clone(in)
+			slices.SortStableFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortStableFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByTrailingNumberFirst(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"a-2", "b-1", "a-1", "b", "a-10", "a", "b-01", "c-2"}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"a", "b", "a-1", "b-1", "b-01", "a-2", "c-2", "a-10"}},
+		{"desc", Desc, []string{"a-10", "c-2", "a-2", "b-1", "b-01", "a-1", "b", "a"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByTrailingNumberFirst(id, test.d)
+			out := slices.Clone(in)
+			slices.SortStableFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortStableFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByTrailingNumberFirstClusters(t *testing.T) {
+	in := []string{"a-2", "b-1", "a-1"}
+	New[string]().ByTrailingNumberFirst(func(s string) string { return s }, Asc).Sort(in)
+	number := func(s string) string {
+		_, n := splitNumericSuffix(s)
+		return n
+	}
+	got := New[string]().ByTrailingNumberFirst(number, Asc).Chunks(in)
+	want := [][]string{{"a-1", "b-1"}, {"a-2"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("clusters = %v, want %v\n\ndiff (-want, +got):\n%v", got, want, diff)
+	}
+}
+
+func TestByDottedVersion(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"1.10", "2024.3.15.7", "1.2.0", "1.9", "1.x", "1.2", "01.02", "1.2.1", "2024.3.15", "1.b"}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"1.2.0", "1.2", "01.02", "1.2.1", "1.9", "1.10", "1.b", "1.x", "2024.3.15", "2024.3.15.7"}},
+		{"desc", Desc, []string{"2024.3.15.7", "2024.3.15", "1.x", "1.b", "1.10", "1.9", "1.2.1", "1.2.0", "1.2", "01.02"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByDottedVersion(id, test.d)
+			out := slices.Clone(in)
+			slices.SortStableFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortStableFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByProductName(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{
+		"iPhone 11 Pro",
+		"Galaxy S9",
+		"iPhone 2",
+		"galaxy s10",
+		"IPHONE 11",
+		"iPad Air 2",
+		"iPhone 11",
+		"iPad Air",
+		"Galaxy S10+",
+		"Pixel 7a",
+		"Pixel 7",
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{
+			name: "asc",
+			d:    Asc,
+			out: []string{
+				"Galaxy S9",
+				"galaxy s10",
+				"Galaxy S10+",
+				"iPad Air",
+				"iPad Air 2",
+				"iPhone 2",
+				"IPHONE 11",
+				"iPhone 11",
+				"iPhone 11 Pro",
+				"Pixel 7",
+				"Pixel 7a",
+			},
+		},
+		{
+			name: "desc",
+			d:    Desc,
+			out: []string{
+				"Pixel 7a",
+				"Pixel 7",
+				"iPhone 11 Pro",
+				"IPHONE 11",
+				"iPhone 11",
+				"iPhone 2",
+				"iPad Air 2",
+				"iPad Air",
+				"Galaxy S10+",
+				"galaxy s10",
+				"Galaxy S9",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByProductName(id, test.d)
+			out := slices.Clone(in)
+			slices.SortStableFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortStableFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+			if err := CheckTotalOrder(s.Less, in); err != nil {
+				t.Errorf("CheckTotalOrder(s.Less, %v) = %v, want nil", in, err)
+			}
+		})
+	}
+}
+
+func TestByMySQLCI(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{
+		"zebra",
+		"Zürich",
+		"Apfel",
+		"éclair",
+		"Æble",
+		"Eclair",
+		"straße",
+		"strasse",
+		"apple ",
+		"Strasse",
+		"Łódź",
+		"apple",
+		"Zurich",
+		"résumé",
+		"Lodz",
+		"resume",
+	}
+	// This is the order of the words in MySQL's utf8_general_ci, with
+	// equivalent words ordered by code point.
+	asc := []string{
+		"Apfel",
+		"apple",
+		"apple ",
+		"Eclair",
+		"éclair",
+		"Lodz",
+		"Łódź",
+		"resume",
+		"résumé",
+		"straße",
+		"Strasse",
+		"strasse",
+		"zebra",
+		"Zurich",
+		"Zürich",
+		"Æble",
+	}
+	desc := slices.Clone(asc)
+	reverse(desc)
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, asc},
+		{"desc", Desc, desc},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByMySQLCI(id, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestCompareIntegerString(t *testing.T) {
+	for _, test := range []struct {
+		l, r string
+		want int
+	}{
+		{"100", "99", 1},
+		{"99", "100", -1},
+		{"-5", "3", -1},
+		{"3", "-5", 1},
+		{"-5", "-30", 1},
+		{"-30", "-5", -1},
+		{"007", "7", 0},
+		{"+7", "7", 0},
+		{"-0", "0", 0},
+		{"-000", "+0", 0},
+		{"123456789012345678901234567890", "123456789012345678901234567891", -1},
+		{"-1", "x", -1},
+		{"x", "99", 1},
+		{"-", "+", 1},
+		{"", "0", 1},
+		{"1.5", "2", 1},
+	} {
+		if got := compareIntegerString(test.l, test.r); got != test.want {
+			t.Errorf("compareIntegerString(%q, %q) = %d, want %d", test.l, test.r, got, test.want)
+		}
+	}
+}
+
+func TestByIntegerString(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"100", "abc", "-5", "99", "3", "-30", "0"}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"-30", "-5", "0", "3", "99", "100", "abc"}},
+		{"desc", Desc, []string{"abc", "100", "99", "3", "0", "-5", "-30"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByIntegerString(id, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestCompareUTF16(t *testing.T) {
+	// compareUTF16 must agree with comparing the UTF-16 encodings.
+	in := []string{"", "a", "ab", "\uFF5E", "\uFF5Ex", "\U0001F600", "\U0001F601", "\U0001F600a", "\U00010000", "\U0010FFFF", "\uD7FF", "\uE000", "z"}
+	for _, l := range in {
+		for _, r := range in {
+			want := compareSlice(utf16.Encode([]rune(l)), utf16.Encode([]rune(r)))
+			if got := compareUTF16(l, r); got != want {
+				t.Errorf("compareUTF16(%+q, %+q) = %d, want %d", l, r, got, want)
+			}
+		}
+	}
+}
+
+func TestByStringUTF16(t *testing.T) {
+	id := func(s string) string { return s }
+	const (
+		tilde = "\uFF5E"     // FULLWIDTH TILDE, in the BMP.
+		smile = "\U0001F600" // GRINNING FACE, a surrogate pair in UTF-16.
+	)
+	in := []string{tilde, "b", smile, "a"}
+	for _, test := range []struct {
+		name string
+		s    *Sorter[string]
+		out  []string
+	}{
+		{"code points", New[string]().ByString(id, Asc), []string{"a", "b", tilde, smile}},
+		{"utf16 asc", New[string]().ByStringUTF16(id, Asc), []string{"a", "b", smile, tilde}},
+		{"utf16 desc", New[string]().ByStringUTF16(id, Desc), []string{tilde, smile, "b", "a"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := slices.Clone(in)
+			slices.SortFunc(out, test.s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%+q) = %+q, want %+q\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestRunePrefix(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"", 3, ""},
+		{"abc", 0, ""},
+		{"abc", -1, ""},
+		{"abc", 2, "ab"},
+		{"abc", 3, "abc"},
+		{"abc", 5, "abc"},
+		{"été", 2, "ét"},
+	} {
+		if got := runePrefix(test.s, test.n); got != test.want {
+			t.Errorf("runePrefix(%q, %d) = %q, want %q", test.s, test.n, got, test.want)
+		}
+	}
+}
+
+func TestByPrefixGroup(t *testing.T) {
+	in := []Data{
+		{String: "info-baz", Int: 3},
+		{String: "error-foo", Int: 1},
+		{String: "err", Int: 4},
+		{String: "error-bar", Int: 2},
+		{String: "error-bar", Int: 5},
+	}
+	s := New[Data]().
+		ByPrefixGroup(func(d Data) string { return d.String }, 5, Asc).
+		ByInt(func(d Data) int { return d.Int }, Desc).
+		ByString(func(d Data) string { return d.String }, Asc)
+	want := []Data{
+		{String: "err", Int: 4},
+		{String: "error-bar", Int: 5},
+		{String: "error-bar", Int: 2},
+		{String: "error-foo", Int: 1},
+		{String: "info-baz", Int: 3},
+	}
+	out := slices.Clone(in)
+	slices.SortFunc(out, s.Less)
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+	}
+}
+
+func TestByRegexpGroup(t *testing.T) {
+	id := func(s string) string { return s }
+	re := regexp.MustCompile(`^ORDER-(\d+)-(\d+)$`)
+	in := []string{"ORDER-2023-0042", "invoice-7", "ORDER-2022-100", "ORDER-2023-9", "ORDER-2021-0042", "bogus"}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"ORDER-2023-9", "ORDER-2021-0042", "ORDER-2023-0042", "ORDER-2022-100", "bogus", "invoice-7"}},
+		{"desc", Desc, []string{"ORDER-2022-100", "ORDER-2021-0042", "ORDER-2023-0042", "ORDER-2023-9", "bogus", "invoice-7"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByRegexpGroup(id, re, 2, test.d).ByString(id, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByRegexpGroupOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ByRegexpGroup(…, 2, …) with one group did not panic")
+		}
+	}()
+	New[string]().ByRegexpGroup(func(s string) string { return s }, regexp.MustCompile(`(\d+)`), 2, Asc)
+}
+
+func TestByLengthBand(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"kubernetes", "go", "rust", "zig", "python", "élan", "typescript", "c", "haskell"}
+	for _, test := range []struct {
+		name  string
+		edges []int
+		d     Dir
+		out   []string
+	}{
+		{"asc", []int{4, 7}, Asc, []string{"c", "go", "zig", "python", "rust", "élan", "haskell", "kubernetes", "typescript"}},
+		{"desc", []int{4, 7}, Desc, []string{"haskell", "kubernetes", "typescript", "python", "rust", "élan", "c", "go", "zig"}},
+		{"no edges", nil, Asc, []string{"c", "go", "haskell", "kubernetes", "python", "rust", "typescript", "zig", "élan"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByLengthBand(id, test.edges, test.d).ByString(id, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByLengthBandUnsorted(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ByLengthBand(…, []int{7, 4}, …) did not panic")
+		}
+	}()
+	New[string]().ByLengthBand(func(s string) string { return s }, []int{7, 4}, Asc)
+}
+
+func TestByStringPrefixAware(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"apple", "app", "b", "application", "ap", "apps"}
+	for _, test := range []struct {
+		name       string
+		shortFirst bool
+		d          Dir
+		out        []string
+	}{
+		{"short first asc", true, Asc, []string{"ap", "app", "apple", "application", "apps", "b"}},
+		{"short last asc", false, Asc, []string{"apple", "application", "apps", "app", "ap", "b"}},
+		{"short first desc", true, Desc, []string{"b", "apps", "application", "apple", "app", "ap"}},
+		{"short last desc", false, Desc, []string{"b", "ap", "app", "apps", "application", "apple"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByStringPrefixAware(id, test.shortFirst, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestCompareBase36(t *testing.T) {
+	for _, test := range []struct {
+		l, r string
+		want int
+	}{
+		{"Z", "10", -1},
+		{"9", "A", -1},
+		{"a1b2", "A1B2", 0},
+		{"00A1B2", "a1b2", 0},
+		{"0", "000", 0},
+		{"ZZZZZZZZZZZZZZZZZZZZ", "100000000000000000000", -1},
+		{"100000000000000000001", "100000000000000000000", 1},
+		{"", "0", 1},
+		{"a-1", "zz", 1},
+		{"a-1", "a-2", -1},
+	} {
+		if got := compareBase36(test.l, test.r); got != test.want {
+			t.Errorf("compareBase36(%q, %q) = %d, want %d", test.l, test.r, got, test.want)
+		}
+		if got := compareBase36(test.r, test.l); got != -test.want {
+			t.Errorf("compareBase36(%q, %q) = %d, want %d", test.r, test.l, got, -test.want)
+		}
+	}
+}
+
+func TestByBase36(t *testing.T) {
+	id := func(s string) string { return s }
+	in := []string{"10", "bad!", "Z", "a1b2", "9", "A", "00z", ""}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"9", "A", "00z", "Z", "10", "a1b2", "", "bad!"}},
+		{"desc", Desc, []string{"bad!", "", "a1b2", "10", "Z", "00z", "A", "9"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[string]().ByBase36(id, test.d).ByString(id, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("slices.SortFunc(%q) = %q, want %q\n\ndiff (-want, +got):\n%v", in, out, test.out, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Package structtag creates an [esort.Sorter] from the struct tags of a type,
+// which suits sorting configured on the type itself rather than in code.  It
+// is separate from package esort, which uses no reflection, because it reads
+// the tagged fields of every element through reflection.
+package structtag
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matttproud/esort"
+	"golang.org/x/exp/slices"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// taggedField is a struct field selected by New.
+type taggedField struct {
+	Field    reflect.StructField
+	Priority int
+	Dir      esort.Dir
+}
+
+// New creates a Sorter from the struct tags with the given key on the fields
+// of the struct type T:
+//
+//	type Person struct {
+//		Surname   string    `sort:"1"`
+//		GivenName string    `sort:"2"`
+//		Born      time.Time `sort:"3,desc"`
+//		Notes     string
+//	}
+//
+//	sorter, err := structtag.New[Person]("sort")
+//
+// A tag holds the field's priority, a positive integer, optionally followed by
+// a comma and a direction of "asc" or "desc", which defaults to "asc".  An
+// instruction is added for each tagged field in order of increasing priority
+// and labeled with the field's name for [esort.Sorter.MarshalText].  Fields
+// without the tag or tagged "-" are ignored, as are the fields of embedded
+// structs.
+//
+// The instruction for a field depends on its kind: fields of kind bool, of any
+// integer or floating-point kind, and of kind string sort like the typed By
+// methods for those kinds, and fields of type time.Time like
+// [esort.Sorter.ByTime].  New returns an error if T is not a struct, a tag is
+// malformed, two fields share a priority, a tagged field is unexported or of
+// another kind, or no field is tagged.
+//
+// The fields are read through reflection, so the Sorter is considerably slower
+// than one built with the typed By methods.
+func New[T any](key string) (*esort.Sorter[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("structtag: type %v is not a struct", t)
+	}
+	var fields []taggedField
+	seen := make(map[int]string)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		v, ok := sf.Tag.Lookup(key)
+		if !ok || v == "-" {
+			continue
+		}
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("structtag: tagged field %s of %v is not exported", sf.Name, t)
+		}
+		f := taggedField{Field: sf}
+		prio, dir, _ := strings.Cut(v, ",")
+		var err error
+		if f.Priority, err = strconv.Atoi(prio); err != nil || f.Priority < 1 {
+			return nil, fmt.Errorf("structtag: tag %q of field %s does not start with a positive priority", v, sf.Name)
+		}
+		switch dir {
+		case "", "asc":
+			f.Dir = esort.Asc
+		case "desc":
+			f.Dir = esort.Desc
+		default:
+			return nil, fmt.Errorf("structtag: tag %q of field %s has direction %q, want \"asc\" or \"desc\"", v, sf.Name, dir)
+		}
+		if other, ok := seen[f.Priority]; ok {
+			return nil, fmt.Errorf("structtag: fields %s and %s share priority %d", other, sf.Name, f.Priority)
+		}
+		seen[f.Priority] = sf.Name
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("structtag: no field of %v has tag %q", t, key)
+	}
+	slices.SortFunc(fields, func(l, r taggedField) bool { return l.Priority < r.Priority })
+	s := esort.New[T]()
+	for _, f := range fields {
+		var err error
+		if s, err = byTaggedField(s, f); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// byTaggedField adds the instruction for f to s, labeled with its name.
+func byTaggedField[T any](s *esort.Sorter[T], f taggedField) (*esort.Sorter[T], error) {
+	i := f.Field.Index
+	field := func(v T) reflect.Value { return reflect.ValueOf(v).FieldByIndex(i) }
+	if f.Field.Type == timeType {
+		s = s.ByTime(func(v T) time.Time { return field(v).Interface().(time.Time) }, f.Dir)
+		return s.Label(f.Field.Name), nil
+	}
+	switch f.Field.Type.Kind() {
+	case reflect.Bool:
+		s = s.ByBool(func(v T) bool { return field(v).Bool() }, f.Dir)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = s.ByInt64(func(v T) int64 { return field(v).Int() }, f.Dir)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s = s.ByUint64(func(v T) uint64 { return field(v).Uint() }, f.Dir)
+	case reflect.Float32, reflect.Float64:
+		s = s.ByFloat64(func(v T) float64 { return field(v).Float() }, f.Dir)
+	case reflect.String:
+		s = s.ByString(func(v T) string { return field(v).String() }, f.Dir)
+	default:
+		return nil, fmt.Errorf("structtag: field %s has unsupported type %v", f.Field.Name, f.Field.Type)
+	}
+	return s.Label(f.Field.Name), nil
+}
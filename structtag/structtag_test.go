@@ -0,0 +1,98 @@
+package structtag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matttproud/esort"
+	"golang.org/x/exp/slices"
+)
+
+type tagged struct {
+	Name    string    `sort:"2"`
+	Born    time.Time `sort:"3,desc"`
+	Score   float64   `sort:"1,desc"`
+	Rank    uint8     `sort:"4,asc"`
+	Pinned  bool      `sort:"5"`
+	Notes   string
+	Ignored int `sort:"-"`
+}
+
+func TestNew(t *testing.T) {
+	s, err := New[tagged]("sort")
+	if err != nil {
+		t.Fatalf("New[tagged](%q) = _, %v, want nil error", "sort", err)
+	}
+	got, err := s.MarshalText()
+	if err != nil {
+		t.Fatalf("s.MarshalText() = _, %v, want nil error", err)
+	}
+	if want := "Score:desc,Name:asc,Born:desc,Rank:asc,Pinned:asc"; string(got) != want {
+		t.Errorf("s.MarshalText() = %q, want %q", got, want)
+	}
+	kinds := s.Stats().ByKind
+	wantKinds := map[esort.Kind]int{esort.KindFloat: 1, esort.KindString: 1, esort.KindTime: 1, esort.KindInteger: 1, esort.KindBool: 1}
+	if diff := cmp.Diff(wantKinds, kinds); diff != "" {
+		t.Errorf("s.Stats().ByKind = %v\n\ndiff (-want, +got):\n%v", kinds, diff)
+	}
+
+	early, late := time.Unix(0, 0), time.Unix(1, 0)
+	in := []tagged{
+		{Name: "b", Score: 1, Born: early},
+		{Name: "a", Score: 1, Born: early, Rank: 2},
+		{Name: "a", Score: 1, Born: early, Rank: 1, Pinned: true},
+		{Name: "a", Score: 1, Born: early, Rank: 1},
+		{Name: "a", Score: 1, Born: late},
+		{Name: "z", Score: 2},
+	}
+	want := []tagged{in[5], in[4], in[3], in[2], in[1], in[0]}
+	out := slices.Clone(in)
+	slices.SortFunc(out, s.Less)
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, out, want, diff)
+	}
+}
+
+func TestNewError(t *testing.T) {
+	type (
+		unsupported struct {
+			Tags []string `sort:"1"`
+		}
+		badPriority struct {
+			Name string `sort:"first"`
+		}
+		badDir struct {
+			Name string `sort:"1,up"`
+		}
+		duplicate struct {
+			Name string `sort:"1"`
+			ID   int    `sort:"1"`
+		}
+		unexported struct {
+			name string `sort:"1"`
+		}
+		untagged struct {
+			Name string
+		}
+	)
+	for _, test := range []struct {
+		name string
+		f    func() error
+	}{
+		{"not a struct", func() error { _, err := New[int]("sort"); return err }},
+		{"unsupported kind", func() error { _, err := New[unsupported]("sort"); return err }},
+		{"bad priority", func() error { _, err := New[badPriority]("sort"); return err }},
+		{"bad direction", func() error { _, err := New[badDir]("sort"); return err }},
+		{"duplicate priority", func() error { _, err := New[duplicate]("sort"); return err }},
+		{"unexported", func() error { _, err := New[unexported]("sort"); return err }},
+		{"untagged", func() error { _, err := New[untagged]("sort"); return err }},
+		{"other tag", func() error { _, err := New[tagged]("order"); return err }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.f(); err == nil {
+				t.Errorf("New() = _, nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package esort
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// compareTime compares the instants l and r three-way.
+func compareTime(l, r time.Time) int {
+	switch {
+	case l.Before(r):
+		return -1
+	case r.Before(l):
+		return 1
+	}
+	return 0
+}
+
+// ByTimePtr sorts the data by a given nullable time value, comparing the
+// instants of present values with [time.Time.Before].  Nil values are placed
+// according to nulls.
+func (s *Sorter[T]) ByTimePtr(f func(T) *time.Time, nulls NullPlacement, d Dir) *Sorter[T] {
+	fn := func(l, r T, d Dir) int {
+		lt, rt := f(l), f(r)
+		if less, ok := nullLess(lt == nil, rt == nil, nulls, d); ok {
+			switch {
+			case less:
+				return -1
+			case (lt == nil) == (rt == nil):
+				return 0
+			}
+			return 1
+		}
+		return compareTime(*lt, *rt)
+	}
+	return s.addInst(inst[T]{CmpDir: fn, Dir: d, Kind: KindTime})
+}
+
+// ByTimeRounded sorts the data by a given time value rounded to the nearest
+// multiple of unit since the zero time as by [time.Time.Round], so that times
+// within the same bucket tie.  Times exactly halfway between two multiples
+// round up into the later bucket.  If unit is not positive, the times are
+// compared without rounding.
+func (s *Sorter[T]) ByTimeRounded(f func(T) time.Time, unit time.Duration, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareTime(f(l).Round(unit), f(r).Round(unit))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindTime})
+}
+
+// ByDeadline sorts the data by the time remaining until a given deadline
+// relative to now, as by [time.Time.Sub], such that expired deadlines have
+// negative remaining times and sort first in ascending order.  now is captured
+// when the instruction is created rather than read from the clock during the
+// sort, which would make comparisons inconsistent as time passes:
+//
+//	sorter := esort.New[Entry]().
+//		ByDeadline(func(e Entry) time.Time { return e.Expiry }, time.Now(), esort.Asc)
+//
+// Remaining times beyond the range of [time.Duration], about 292 years, are
+// saturated and tie.
+func (s *Sorter[T]) ByDeadline(f func(T) time.Time, now time.Time, d Dir) *Sorter[T] {
+	key := func(v T) time.Duration { return f(v).Sub(now) }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d, Kind: KindTime})
+}
+
+// ByDayOfYear sorts the data by the day of the year of a given time in loc, as
+// by [time.Time.YearDay], ignoring the year, which suits comparing seasonal
+// data across years.  loc must not be nil.
+//
+// Days are numbered from January 1 without regard for leap years, so from
+// March 1 onward a date in a leap year has the number of the following date in
+// other years: March 1, 2024, is day 61 and ties with March 2, 2023.  December
+// 31 of a leap year is day 366 and sorts after all days of other years.
+func (s *Sorter[T]) ByDayOfYear(f func(T) time.Time, loc *time.Location, d Dir) *Sorter[T] {
+	key := func(v T) int { return f(v).In(loc).YearDay() }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d, Kind: KindTime})
+}
+
+// ByTime sorts the data by a given time value, comparing instants with
+// [time.Time.Before] regardless of location.
+func (s *Sorter[T]) ByTime(f func(T) time.Time, d Dir) *Sorter[T] {
+	fn := func(l, r T) int {
+		return compareTime(f(l), f(r))
+	}
+	return s.addInst(inst[T]{Cmp: fn, Dir: d, Kind: KindTime})
+}
+
+// ByRecencyBucket sorts the data by the bucket that the age of a given time
+// relative to now falls into, so that items of similar age tie and later
+// instructions can order them.  edges lists the ages separating the buckets in
+// ascending order: an age below edges[0] falls into bucket 0, an age in
+// [edges[i-1], edges[i]) into bucket i, and an age at or above the last edge
+// into bucket len(edges).  Times after now fall into bucket 0.  In ascending
+// order the most recent bucket sorts first.  For instance, to group a feed
+// into today, this week, and older, newest first within each group:
+//
+//	edges := []time.Duration{24 * time.Hour, 7 * 24 * time.Hour}
+//	sorter := esort.New[Post]().
+//		ByRecencyBucket(func(p Post) time.Time { return p.Published }, now, edges, esort.Asc).
+//		ByTime(func(p Post) time.Time { return p.Published }, esort.Desc)
+//
+// ByRecencyBucket panics if edges is not sorted in ascending order.  edges is
+// copied, so later modifications to it have no effect on the Sorter.
+func (s *Sorter[T]) ByRecencyBucket(f func(T) time.Time, now time.Time, edges []time.Duration, d Dir) *Sorter[T] {
+	if !slices.IsSorted(edges) {
+		panic(fmt.Errorf("esort: edges %v are not sorted in ascending order", edges))
+	}
+	edges = slices.Clone(edges)
+	key := func(v T) int { return bandIndex(edges, now.Sub(f(v))) }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d, Kind: KindTime})
+}
+
+// ByTimeCoalesce sorts the data by the first non-zero time returned by the
+// functions in fs, like SQL's COALESCE, such as a record's update time if it
+// has one and its creation time otherwise:
+//
+//	sorter := esort.New[Record]().ByTimeCoalesce([]func(Record) time.Time{
+//		func(r Record) time.Time { return r.UpdatedAt },
+//		func(r Record) time.Time { return r.CreatedAt },
+//	}, esort.Desc)
+//
+// The functions are called in order until one returns a time for which
+// [time.Time.IsZero] is false.  Elements for which all of them return the zero
+// time are compared as the zero time, which precedes all other times in
+// ascending order.  Times are compared like [Sorter.ByTime].  fs is copied, so
+// later modifications to it have no effect on the Sorter.
+func (s *Sorter[T]) ByTimeCoalesce(fs []func(T) time.Time, d Dir) *Sorter[T] {
+	fs = slices.Clone(fs)
+	return s.ByTime(func(v T) time.Time {
+		for _, f := range fs {
+			if t := f(v); !t.IsZero() {
+				return t
+			}
+		}
+		return time.Time{}
+	}, d)
+}
+
+// ByNanosecondOfSecond sorts the data by the nanosecond offset within the
+// second of a given time, as reported by [time.Time.Nanosecond], ignoring the
+// whole seconds, such as to study the sub-second jitter of periodic events.
+// Times whose offsets are equal tie regardless of their seconds.
+func (s *Sorter[T]) ByNanosecondOfSecond(f func(T) time.Time, d Dir) *Sorter[T] {
+	key := func(v T) int { return f(v).Nanosecond() }
+	return s.addInst(inst[T]{Cmp: compareFunc(key), Dir: d, Kind: KindTime})
+}
@@ -0,0 +1,351 @@
+package esort
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slices"
+)
+
+func TestByTimePtr(t *testing.T) {
+	type event struct {
+		Name string
+		At   *time.Time
+	}
+	at := func(e event) *time.Time { return e.At }
+	t0 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	// t1Local is the same instant as t1 in a different location.
+	t1Local := t1.In(time.FixedZone("UTC+1", 60*60))
+	t2 := t0.Add(2 * time.Hour)
+	in := []event{
+		{"b", &t1Local},
+		{"nil1", nil},
+		{"c", &t2},
+		{"a", &t0},
+		{"nil2", nil},
+	}
+	for _, test := range []struct {
+		name  string
+		nulls NullPlacement
+		d     Dir
+		out   []string
+	}{
+		{"nulls first asc", NullsFirst, Asc, []string{"nil1", "nil2", "a", "b", "c"}},
+		{"nulls last asc", NullsLast, Asc, []string{"a", "b", "c", "nil1", "nil2"}},
+		{"nulls first desc", NullsFirst, Desc, []string{"nil1", "nil2", "c", "b", "a"}},
+		{"nulls last desc", NullsLast, Desc, []string{"c", "b", "a", "nil1", "nil2"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[event]().ByTimePtr(at, test.nulls, test.d)
+			out := slices.Clone(in)
+			slices.SortStableFunc(out, s.Less)
+			var got []string
+			for _, e := range out {
+				got = append(got, e.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortStableFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByTimeRounded(t *testing.T) {
+	type event struct {
+		Name string
+		At   time.Time
+	}
+	at := func(e event) time.Time { return e.At }
+	name := func(e event) string { return e.Name }
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	in := []event{
+		{"d", base.Add(30*time.Minute + time.Second)}, // Just past half: rounds up to 13:00.
+		{"c", base.Add(29 * time.Minute)},             // Rounds down to 12:00.
+		{"b", base.Add(-29 * time.Minute)},            // Rounds up to 12:00.
+		{"a", base.Add(30 * time.Minute)},             // Exactly half: rounds up to 13:00.
+		{"e", base.Add(-31 * time.Minute)},            // Rounds down to 11:00.
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"e", "b", "c", "a", "d"}},
+		{"desc", Desc, []string{"a", "d", "b", "c", "e"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[event]().ByTimeRounded(at, time.Hour, test.d).ByString(name, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, e := range out {
+				got = append(got, e.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByDeadline(t *testing.T) {
+	type entry struct {
+		Name   string
+		Expiry time.Time
+	}
+	expiry := func(e entry) time.Time { return e.Expiry }
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	in := []entry{
+		{"future", now.Add(time.Hour)},
+		{"long expired", now.Add(-24 * time.Hour)},
+		{"now", now},
+		{"soon", now.Add(time.Minute).In(time.FixedZone("UTC+1", 60*60))},
+		{"expired", now.Add(-time.Second)},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"long expired", "expired", "now", "soon", "future"}},
+		{"desc", Desc, []string{"future", "soon", "now", "expired", "long expired"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[entry]().ByDeadline(expiry, now, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, e := range out {
+				got = append(got, e.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByDayOfYear(t *testing.T) {
+	type event struct {
+		Name string
+		At   time.Time
+	}
+	at := func(e event) time.Time { return e.At }
+	name := func(e event) string { return e.Name }
+	utc := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 12, 0, 0, 0, time.UTC) }
+	in := []event{
+		{"dec 31 2024", utc(2024, time.December, 31)},
+		{"mar 1 2024", utc(2024, time.March, 1)},
+		{"jan 1 2020", utc(2020, time.January, 1)},
+		{"mar 2 2023", utc(2023, time.March, 2)},
+		{"dec 31 2023", utc(2023, time.December, 31)},
+		{"feb 28 2023", utc(2023, time.February, 28)},
+		{"jun 15 2019", utc(2019, time.June, 15)},
+		// Late on December 31 in UTC is already January 1 in UTC+2.
+		{"dec 31 2021 late", time.Date(2021, time.December, 31, 23, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range []struct {
+		name string
+		loc  *time.Location
+		d    Dir
+		out  []string
+	}{
+		{
+			name: "utc asc",
+			loc:  time.UTC,
+			d:    Asc,
+			out:  []string{"jan 1 2020", "feb 28 2023", "mar 1 2024", "mar 2 2023", "jun 15 2019", "dec 31 2021 late", "dec 31 2023", "dec 31 2024"},
+		},
+		{
+			name: "utc desc",
+			loc:  time.UTC,
+			d:    Desc,
+			out:  []string{"dec 31 2024", "dec 31 2021 late", "dec 31 2023", "jun 15 2019", "mar 1 2024", "mar 2 2023", "feb 28 2023", "jan 1 2020"},
+		},
+		{
+			name: "utc+2 asc",
+			loc:  time.FixedZone("UTC+2", 2*60*60),
+			d:    Asc,
+			out:  []string{"dec 31 2021 late", "jan 1 2020", "feb 28 2023", "mar 1 2024", "mar 2 2023", "jun 15 2019", "dec 31 2023", "dec 31 2024"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[event]().ByDayOfYear(at, test.loc, test.d).ByString(name, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, e := range out {
+				got = append(got, e.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByTime(t *testing.T) {
+	type event struct {
+		Name string
+		At   time.Time
+	}
+	at := func(e event) time.Time { return e.At }
+	t0 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := []event{
+		{"b", t0.Add(time.Hour).In(time.FixedZone("UTC-5", -5*60*60))},
+		{"c", t0.Add(2 * time.Hour)},
+		{"a", t0},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"a", "b", "c"}},
+		{"desc", Desc, []string{"c", "b", "a"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[event]().ByTime(at, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, e := range out {
+				got = append(got, e.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByRecencyBucket(t *testing.T) {
+	type post struct {
+		Name      string
+		Published time.Time
+	}
+	published := func(p post) time.Time { return p.Published }
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	edges := []time.Duration{day, 7 * day}
+	in := []post{
+		{"week old", now.Add(-7 * day)},
+		{"hour old", now.Add(-time.Hour)},
+		{"month old", now.Add(-30 * day)},
+		{"future", now.Add(time.Hour)},
+		{"two days old", now.Add(-2 * day)},
+		{"almost a day old", now.Add(-day + time.Second)},
+		{"day old", now.Add(-day)},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{
+			name: "asc",
+			d:    Asc,
+			out:  []string{"future", "hour old", "almost a day old", "day old", "two days old", "week old", "month old"},
+		},
+		{
+			name: "desc",
+			d:    Desc,
+			out:  []string{"week old", "month old", "day old", "two days old", "future", "hour old", "almost a day old"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[post]().
+				ByRecencyBucket(published, now, edges, test.d).
+				ByTime(published, Desc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, p := range out {
+				got = append(got, p.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByTimeCoalesce(t *testing.T) {
+	type record struct {
+		Name             string
+		Updated, Created time.Time
+	}
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := []record{
+		{"created only", time.Time{}, base.Add(2 * time.Hour)},
+		{"updated late", base.Add(5 * time.Hour), base},
+		{"neither", time.Time{}, time.Time{}},
+		{"updated early", base.Add(time.Hour), base.Add(-time.Hour)},
+		{"created late", time.Time{}, base.Add(3 * time.Hour)},
+	}
+	fs := []func(record) time.Time{
+		func(r record) time.Time { return r.Updated },
+		func(r record) time.Time { return r.Created },
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []string
+	}{
+		{"asc", Asc, []string{"neither", "updated early", "created only", "created late", "updated late"}},
+		{"desc", Desc, []string{"updated late", "created late", "created only", "updated early", "neither"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[record]().ByTimeCoalesce(fs, test.d)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []string
+			for _, r := range out {
+				got = append(got, r.Name)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}
+
+func TestByNanosecondOfSecond(t *testing.T) {
+	type event struct {
+		ID   int
+		Time time.Time
+	}
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := []event{
+		{1, base.Add(5*time.Second + 300*time.Millisecond)},
+		{2, base.Add(1*time.Second + 700*time.Millisecond)},
+		{3, base.Add(9*time.Second + 300*time.Millisecond)},
+		{4, base.Add(2 * time.Second)},
+		{5, base.Add(300 * time.Millisecond)},
+	}
+	for _, test := range []struct {
+		name string
+		d    Dir
+		out  []int
+	}{
+		{"asc", Asc, []int{4, 1, 3, 5, 2}},
+		{"desc", Desc, []int{2, 1, 3, 5, 4}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := New[event]().
+				ByNanosecondOfSecond(func(e event) time.Time { return e.Time }, test.d).
+				ByInt(func(e event) int { return e.ID }, Asc)
+			out := slices.Clone(in)
+			slices.SortFunc(out, s.Less)
+			var got []int
+			for _, e := range out {
+				got = append(got, e.ID)
+			}
+			if diff := cmp.Diff(test.out, got); diff != "" {
+				t.Errorf("slices.SortFunc(%v) = %v, want %v\n\ndiff (-want, +got):\n%v", in, got, test.out, diff)
+			}
+		})
+	}
+}